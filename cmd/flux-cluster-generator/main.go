@@ -35,6 +35,11 @@ Context: .name .namespace .labels .annotations
 Funcs: label, ann, default, coalesce, dns1123, projectFromNS
 Example: '{{ dns1123 (coalesce (label "vci.flux.loft.sh/project") (projectFromNS .namespace)) }}-{{ dns1123 (coalesce (label "vci.flux.loft.sh/name") .name) }}'`)
 
+	flag.StringVar(&opts.RSIPNamespaceTemplateStr, "rsip-namespace-template", "",
+		`Go template to compute the namespace of each RSIP (same context and funcs as --rsip-name-template).
+Falls back to --rsip-namespace when empty or invalid.
+Example: 'flux-{{ projectFromNS .namespace }}'`)
+
 	// kept for fallback when template is empty
 	flag.StringVar(&opts.ClusterNameKey, "cluster-name-label-key", "vci.flux.loft.sh/name", "Label key on the Secret to derive cluster name")
 	flag.StringVar(&opts.ProjectLabelKey, "project-label-key", "vci.flux.loft.sh/project", "Label key on the Secret containing the VCI project")
@@ -49,8 +54,117 @@ Example: '{{ dns1123 (coalesce (label "vci.flux.loft.sh/project") (projectFromNS
 	var cacheSyncSeconds int
 	flag.IntVar(&cacheSyncSeconds, "cache-sync-seconds", 120, "Cache sync timeout (seconds)")
 
+	// reachability probing
+	flag.BoolVar(&opts.ProbeEnabled, "probe-enabled", false, "Probe /version and /readyz of each cluster via its kubeconfig and record health on the RSIP")
+	var probeIntervalSeconds, probeTimeoutSeconds, probeThresholdSeconds int
+	flag.IntVar(&probeIntervalSeconds, "probe-interval-seconds", 60, "Interval between reachability probes (seconds)")
+	flag.IntVar(&probeTimeoutSeconds, "probe-timeout-seconds", 10, "Timeout for a single reachability probe (seconds)")
+	flag.StringVar(&opts.ProbeUnreachablePolicy, "probe-unreachable-policy", "pause", "What to do with RSIPs of clusters unreachable past the threshold: pause (keep, mark paused) or withdraw (delete)")
+	flag.IntVar(&probeThresholdSeconds, "probe-unreachable-threshold-seconds", 300, "How long a cluster must be unreachable before the policy applies (seconds)")
+
+	// capability discovery
+	flag.BoolVar(&opts.CapabilitiesEnabled, "capabilities-enabled", false, "Run API discovery against each cluster and publish capability.fcg.io/* labels on the RSIP")
+	flag.StringVar(&opts.CapabilitiesCSV, "capabilities", "", "Comma-separated capability checks name=group[/resource] (e.g. gateway-api=gateway.networking.k8s.io,istio=networking.istio.io/virtualservices)")
+	flag.StringVar(&opts.CapabilityGPUResource, "capability-gpu-resource", "nvidia.com/gpu", "Node allocatable resource that marks a cluster as GPU-capable (empty disables)")
+	var capabilityIntervalSeconds int
+	flag.IntVar(&capabilityIntervalSeconds, "capability-interval-seconds", 600, "Interval between capability discovery runs (seconds)")
+
+	// self-described cluster metadata
+	flag.StringVar(&opts.FleetInfoConfigMap, "fleet-info-configmap", "", "namespace/name of a ConfigMap read from each target cluster and merged into the RSIP (e.g. kube-system/fleet-info; empty disables)")
+	flag.StringVar(&opts.FleetInfoKeysCSV, "fleet-info-keys", "region,tier,team", "Comma-separated ConfigMap keys allowed to be merged into the RSIP")
+	var fleetInfoIntervalSeconds int
+	flag.IntVar(&fleetInfoIntervalSeconds, "fleet-info-interval-seconds", 600, "Interval between fleet info reads (seconds)")
+
+	// Argo CD cluster Secret source
+	flag.BoolVar(&opts.ArgoCDSourceEnabled, "argocd-source", false, "Also generate RSIPs from Argo CD cluster Secrets (argocd.argoproj.io/secret-type=cluster)")
+	flag.StringVar(&opts.ArgoCDNamespace, "argocd-namespace", "argocd", "Namespace containing Argo CD cluster Secrets")
+	flag.StringVar(&opts.ArgoCDTargetNamespace, "argocd-target-namespace", "", "Namespace for Flux kubeconfig Secrets synthesized from Argo CD cluster Secrets (default: --rsip-namespace)")
+
+	// Argo CD cluster Secret output
+	flag.BoolVar(&opts.ArgoCDOutputEnabled, "argocd-output", false, "Also maintain an Argo CD cluster Secret for every qualifying kubeconfig Secret")
+	flag.StringVar(&opts.ArgoCDOutputNamespace, "argocd-output-namespace", "argocd", "Namespace for Argo CD cluster Secrets written by --argocd-output")
+
+	// Cluster API source
+	flag.BoolVar(&opts.CAPISourceEnabled, "capi-source", false, "Also generate RSIPs from Cluster API Clusters (via their <cluster>-kubeconfig Secret) once ControlPlaneReady")
+
+	// vCluster Platform enrichment
+	flag.BoolVar(&opts.VCIEnrichmentEnabled, "vci-enrichment", false, "Enrich RSIPs from the owning vCluster Platform VirtualClusterInstance (via vci.flux.loft.sh/* Secret labels)")
+	flag.StringVar(&opts.VCISleepPolicy, "vci-sleep-policy", "mark", "What to do with RSIPs of sleeping VirtualClusterInstances: mark (label sleeping=true) or withdraw (delete)")
+
+	// workload-identity kubeconfig ConfigMaps
+	flag.StringVar(&opts.ConfigMapLabelSelectorStr, "configmap-label-selector", "", "Label selector for Flux kubeConfig.configMapRef ConfigMaps (provider/cluster/address) to generate RSIPs from (empty disables)")
+
+	// Open Cluster Management source
+	flag.BoolVar(&opts.OCMSourceEnabled, "ocm-source", false, "Also generate RSIPs from Open Cluster Management ManagedClusters once ManagedClusterConditionAvailable")
+	flag.StringVar(&opts.OCMSecretTemplateStr, "ocm-secret-template", "{{ .name }}/{{ .name }}-kubeconfig", "Go template rendering namespace/name of the kubeconfig Secret paired with a ManagedCluster (fields: .name, .labels, .annotations)")
+
+	// SIG-Multicluster ClusterProfiles
+	flag.BoolVar(&opts.ClusterProfileSourceEnabled, "clusterprofile-source", false, "Also generate RSIPs from multicluster.x-k8s.io ClusterProfiles (properties and credential providers)")
+	flag.BoolVar(&opts.ClusterProfileOutputEnabled, "clusterprofile-output", false, "Also publish a ClusterProfile for every qualifying kubeconfig Secret")
+	flag.StringVar(&opts.ClusterProfileOutputNamespace, "clusterprofile-output-namespace", "fleet-system", "Namespace for ClusterProfiles written by --clusterprofile-output")
+
+	// static inventory file
+	flag.StringVar(&opts.InventoryFile, "inventory-file", "", "Path to a YAML cluster inventory (name, secretRef, labels) used as an additional source; hot-reloaded (empty disables)")
+
+	// external CMDB
+	flag.StringVar(&opts.CMDBURL, "cmdb-url", "", "URL serving a JSON list of clusters (name, attributes, secretRef) whose attributes are merged into RSIPs by cluster name (empty disables)")
+	flag.StringVar(&opts.CMDBBearerTokenFile, "cmdb-bearer-token-file", "", "File holding a bearer token sent to --cmdb-url (re-read on every poll)")
+	var cmdbIntervalSeconds int
+	flag.IntVar(&cmdbIntervalSeconds, "cmdb-interval-seconds", 300, "Interval between CMDB polls (seconds)")
+	flag.BoolVar(&opts.CMDBGenerate, "cmdb-generate", false, "Also generate RSIPs for CMDB clusters that carry a secretRef")
+
+	// remote management clusters (hub-of-hubs)
+	flag.StringVar(&opts.RemoteKubeconfigsCSV, "remote-kubeconfigs", "", "Comma-separated name=path kubeconfigs of remote management clusters whose selected kubeconfig Secrets are copied into the hub (e.g. eu=/etc/remotes/eu,us=/etc/remotes/us)")
+	flag.StringVar(&opts.RemoteTargetNamespace, "remote-target-namespace", "", "Namespace for kubeconfig Secrets copied from remote management clusters (default: --rsip-namespace)")
+
+	// RSIP for the management cluster itself
+	flag.BoolVar(&opts.LocalClusterEnabled, "local-cluster", false, "Also maintain an RSIP for the management cluster itself (empty kubeSecretName, inCluster=true)")
+	flag.StringVar(&opts.LocalClusterName, "local-cluster-name", "in-cluster", "Cluster name of the local cluster RSIP")
+	flag.StringVar(&opts.LocalClusterLabelsCSV, "local-cluster-labels", "", "Comma-separated key=value labels of the local cluster (used for naming and label-copy, and set on the RSIP)")
+	flag.StringVar(&opts.LocalClusterValuesCSV, "local-cluster-values", "", "Comma-separated key=value defaultValues added to the local cluster RSIP")
+
+	// kubeconfig replication
+	flag.BoolVar(&opts.ReplicateKubeconfigs, "replicate-kubeconfigs", false, "Copy the kubeconfig key of each source Secret into the RSIP namespace and point kubeSecretName/kubeSecretNS at the copy")
+
+	// fan-out
+	flag.StringVar(&opts.FanoutNamespacesCSV, "fanout-namespaces", "", "Comma-separated namespaces each Secret's RSIP is written to (instead of --rsip-namespace)")
+	flag.StringVar(&opts.FanoutNamespaceSelectorStr, "fanout-namespace-selector", "", "Label selector for Namespaces each Secret's RSIP is written to (combined with --fanout-namespaces)")
+
+	// per-app RSIPs
+	flag.BoolVar(&opts.AppFanout, "app-fanout", false, "Generate one RSIP per app enabled with a '<app-label-prefix><app>: true' label instead of one per cluster")
+	flag.StringVar(&opts.AppLabelPrefix, "app-label-prefix", "flux-app/", "Label key prefix of the per-app switches used by --app-fanout")
+
+	// matrix
+	flag.StringVar(&opts.MatrixNamespace, "matrix-namespace", "", "Namespace of the list ConfigMaps Secrets annotated fcg.io/matrix are crossed with (empty disables)")
+
+	// resourceset inputs sink
+	flag.BoolVar(&opts.ResourceSetInputsEnabled, "resourceset-inputs", false, "Maintain spec.inputs of ResourceSets annotated fcg.io/inputs-selector with the values of every matching cluster (server-side apply)")
+
+	// resourceset reconciliation requests
+	var resourceSetTriggerIntervalSeconds int
+	flag.BoolVar(&opts.ResourceSetTriggerEnabled, "resourceset-trigger", false, "Annotate ResourceSets whose inputsFrom select a created, updated or deleted RSIP with reconcile.fluxcd.io/requestedAt")
+	flag.IntVar(&resourceSetTriggerIntervalSeconds, "resourceset-trigger-interval-seconds", 5, "Batching window of --resourceset-trigger; each ResourceSet is requested at most once per window")
+
+	// rollout status
+	flag.BoolVar(&opts.RolloutStatusEnabled, "rollout-status", false, "Roll the Ready conditions of HelmReleases/Kustomizations labeled mirror.fluxcd.io/clusterName up per cluster (RSIP annotations, metrics, summary ConfigMap)")
+	flag.StringVar(&opts.RolloutStatusConfigMap, "rollout-status-configmap", "fleet-rollout-status", "Name of the rollout summary ConfigMap in --rsip-namespace (empty disables it)")
+
+	// fleet summary
+	flag.StringVar(&opts.FleetSummaryConfigMap, "fleet-summary-configmap", "", "Name of the fleet inventory summary ConfigMap listing every generated RSIP (empty disables)")
+	flag.StringVar(&opts.FleetSummaryNamespace, "fleet-summary-namespace", "", "Namespace of the fleet summary ConfigMap, normally the controller's (default --rsip-namespace)")
+
+	// inventory API
+	flag.StringVar(&opts.InventoryAPIBindAddress, "inventory-api-bind-address", "", "Address serving the read-only fleet inventory at /v1/clusters and Prometheus http_sd targets at /v1/http-sd, e.g. :8082 (empty disables)")
+
 	flag.Parse()
 	opts.CacheSyncTimeout = time.Duration(cacheSyncSeconds) * time.Second
+	opts.ProbeInterval = time.Duration(probeIntervalSeconds) * time.Second
+	opts.ProbeTimeout = time.Duration(probeTimeoutSeconds) * time.Second
+	opts.ProbeUnreachableThreshold = time.Duration(probeThresholdSeconds) * time.Second
+	opts.CapabilityInterval = time.Duration(capabilityIntervalSeconds) * time.Second
+	opts.FleetInfoInterval = time.Duration(fleetInfoIntervalSeconds) * time.Second
+	opts.CMDBInterval = time.Duration(cmdbIntervalSeconds) * time.Second
+	opts.ResourceSetTriggerInterval = time.Duration(resourceSetTriggerIntervalSeconds) * time.Second
 
 	// Parse template (if provided)
 	if opts.RSIPNameTemplateStr != "" {
@@ -63,6 +177,27 @@ Example: '{{ dns1123 (coalesce (label "vci.flux.loft.sh/project") (projectFromNS
 		}
 		opts.RSIPNameTemplate = tmpl
 	}
+	if opts.RSIPNamespaceTemplateStr != "" {
+		tmpl, err := template.New("rsipNamespace").
+			Funcs(controller.TemplateFuncMap()).
+			Parse(opts.RSIPNamespaceTemplateStr)
+		if err != nil {
+			logger.Error(err, "invalid --rsip-namespace-template")
+			os.Exit(1)
+		}
+		opts.RSIPNamespaceTemplate = tmpl
+	}
+
+	if opts.OCMSourceEnabled {
+		tmpl, err := template.New("ocmSecret").
+			Funcs(controller.TemplateFuncMap()).
+			Parse(opts.OCMSecretTemplateStr)
+		if err != nil {
+			logger.Error(err, "invalid --ocm-secret-template")
+			os.Exit(1)
+		}
+		opts.OCMSecretTemplate = tmpl
+	}
 
 	// parse selectors / CSVs and validate required fields
 	if err := opts.FillAndValidate(); err != nil {
@@ -0,0 +1,41 @@
+// internal/controller/app_fanout.go
+package controller
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// appLabel names the application of a per-app RSIP (--app-fanout).
+	appLabel = "mirror.fluxcd.io/app"
+	// appValuesAnnotationSuffix follows the app name in the annotation domain of app-scoped
+	// values: <app>.values.fcg.io/<key>.
+	appValuesAnnotationSuffix = ".values.fcg.io/"
+)
+
+// appVariants returns one variant per app enabled with a "<prefix><app>: 'true'" label,
+// ordered by app, with the values of its "<app>.values.fcg.io/<key>" annotations.
+func appVariants(prefix string, lbls, annotations map[string]string) []rsipVariant {
+	var out []rsipVariant
+	for _, k := range slices.Sorted(maps.Keys(lbls)) {
+		app, ok := strings.CutPrefix(k, prefix)
+		if !ok || app == "" || lbls[k] != "true" {
+			continue
+		}
+		v := rsipVariant{
+			suffixes: []string{app},
+			labels:   map[string]string{appLabel: sanitizeLabelValue(app)},
+			ids:      map[string]any{"app": app},
+			values:   map[string]string{},
+		}
+		for ak, av := range annotations {
+			if key, ok := strings.CutPrefix(ak, app+appValuesAnnotationSuffix); ok && key != "" {
+				v.values[key] = av
+			}
+		}
+		out = append(out, v)
+	}
+	return out
+}
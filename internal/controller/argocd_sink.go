@@ -0,0 +1,192 @@
+// internal/controller/argocd_sink.go
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// argoOutputLabel marks Argo CD cluster Secrets written by the controller.
+const argoOutputLabel = "mirror.fluxcd.io/argocdOutput"
+
+// isArgoOutput reports whether obj is an Argo CD cluster Secret written by the controller.
+func isArgoOutput(obj client.Object) bool {
+	return obj.GetLabels()[argoOutputLabel] == "true"
+}
+
+// argoClusterWriter maintains an Argo CD cluster Secret for every qualifying Flux
+// kubeconfig Secret, so both tools share one inventory during a migration.
+type argoClusterWriter struct {
+	Client    client.Client
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Opts      Options
+}
+
+// ensure creates or updates the Argo CD cluster Secret for sec. lbls are the RSIP labels;
+// copied labels and the mirror.fluxcd.io/* bookkeeping labels are carried over.
+func (w *argoClusterWriter) ensure(ctx context.Context, sec *corev1.Secret, clusterName string, lbls map[string]string) error {
+	log := ctrl.Log.WithName("argocd-output").WithValues("secret", client.ObjectKeyFromObject(sec).String())
+
+	server, config, err := argoConfigFromSecret(sec, kubeconfigKey(sec, w.Opts))
+	if err != nil {
+		// a Secret written for the previous kubeconfig would keep Argo CD on stale credentials
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ArgoClusterSecretSkipped",
+			"cannot translate kubeconfig for Argo CD, removing its cluster Secret: %v", err)
+		log.Info("skipping Argo CD cluster secret", "reason", err.Error())
+		return w.remove(ctx, client.ObjectKeyFromObject(sec))
+	}
+
+	desiredLabels := maps.Clone(lbls)
+	desiredLabels[argoSecretTypeLabel] = "cluster"
+	desiredLabels[argoOutputLabel] = "true"
+
+	desired := &corev1.Secret{}
+	desired.SetNamespace(w.Opts.ArgoCDOutputNamespace)
+	desired.SetName(argoOutputSecretName(sec))
+	desired.SetLabels(desiredLabels)
+	desired.Type = corev1.SecretTypeOpaque
+	desired.Data = map[string][]byte{
+		"name":   []byte(clusterName),
+		"server": []byte(server),
+		"config": config,
+	}
+
+	var existing corev1.Secret
+	err = w.Client.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err != nil {
+		if err := w.Client.Create(ctx, desired); err != nil {
+			w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ArgoClusterSecretCreateFailed",
+				"failed to create Argo CD cluster Secret %s/%s: %v", desired.Namespace, desired.Name, err)
+			return err
+		}
+		w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ArgoClusterSecretCreated",
+			"created Argo CD cluster Secret %s/%s", desired.Namespace, desired.Name)
+		log.Info("created Argo CD cluster secret", "name", desired.Name, "ns", desired.Namespace)
+		// the Secret may have been written under an earlier name
+		return w.removeExcept(ctx, client.ObjectKeyFromObject(sec), desired.Name)
+	}
+
+	if !isArgoOutput(&existing) ||
+		existing.Labels["mirror.fluxcd.io/secretNS"] != sec.Namespace ||
+		existing.Labels["mirror.fluxcd.io/secretName"] != sec.Name {
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ArgoClusterSecretConflict",
+			"Secret %s/%s exists and was not written for this Secret; not overwriting it", existing.Namespace, existing.Name)
+		return fmt.Errorf("secret %s/%s exists and was not written for %s/%s", existing.Namespace, existing.Name, sec.Namespace, sec.Name)
+	}
+
+	if maps.Equal(existing.Labels, desired.Labels) && maps.EqualFunc(existing.Data, desired.Data, bytes.Equal) {
+		log.V(1).Info("Argo CD cluster secret up-to-date", "name", desired.Name)
+		return nil
+	}
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+	if err := w.Client.Update(ctx, &existing); err != nil {
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ArgoClusterSecretUpdateFailed",
+			"failed to update Argo CD cluster Secret %s/%s: %v", existing.Namespace, existing.Name, err)
+		return err
+	}
+	w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ArgoClusterSecretUpdated",
+		"updated Argo CD cluster Secret %s/%s", existing.Namespace, existing.Name)
+	log.Info("updated Argo CD cluster secret", "name", existing.Name)
+	return nil
+}
+
+// remove deletes the Argo CD cluster Secrets written for a source Secret.
+func (w *argoClusterWriter) remove(ctx context.Context, secretNN types.NamespacedName) error {
+	return w.removeExcept(ctx, secretNN, "")
+}
+
+// removeExcept deletes the Argo CD cluster Secrets written for a source Secret other than keep.
+func (w *argoClusterWriter) removeExcept(ctx context.Context, secretNN types.NamespacedName, keep string) error {
+	log := ctrl.Log.WithName("argocd-output")
+
+	var list corev1.SecretList
+	if err := w.APIReader.List(ctx, &list,
+		client.InNamespace(w.Opts.ArgoCDOutputNamespace),
+		client.MatchingLabels{
+			argoOutputLabel:               "true",
+			"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
+			"mirror.fluxcd.io/secretName": secretNN.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("list Argo CD cluster secrets: %w", err)
+	}
+	for i := range list.Items {
+		if list.Items[i].Name == keep {
+			continue
+		}
+		if err := w.Client.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted Argo CD cluster secret", "name", list.Items[i].Name, "secret", secretNN.String())
+	}
+	return nil
+}
+
+// argoOutputSecretName is the Argo CD cluster Secret name for a source Secret. The namespace
+// is a DNS label, so the dots keep every namespace/name combination distinct.
+func argoOutputSecretName(sec *corev1.Secret) string {
+	name := "cluster." + sec.Namespace + "." + sec.Name
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return name
+}
+
+// argoConfigFromSecret translates the kubeconfig in sec.Data[key] into Argo CD's server and config JSON.
+func argoConfigFromSecret(sec *corev1.Secret, key string) (string, []byte, error) {
+	cfg, err := restConfigFromSecret(sec, key)
+	if err != nil {
+		return "", nil, err
+	}
+	if cfg.BearerTokenFile != "" || cfg.TLSClientConfig.CAFile != "" ||
+		cfg.TLSClientConfig.CertFile != "" || cfg.TLSClientConfig.KeyFile != "" {
+		return "", nil, fmt.Errorf("kubeconfig references local files, which Argo CD cannot read")
+	}
+	if cfg.AuthProvider != nil {
+		return "", nil, fmt.Errorf("auth-provider %q is not supported", cfg.AuthProvider.Name)
+	}
+
+	var ac argoClusterConfig
+	ac.BearerToken = cfg.BearerToken
+	ac.Username = cfg.Username
+	ac.Password = cfg.Password
+	ac.TLSClientConfig.Insecure = cfg.TLSClientConfig.Insecure
+	ac.TLSClientConfig.ServerName = cfg.TLSClientConfig.ServerName
+	ac.TLSClientConfig.CAData = cfg.TLSClientConfig.CAData
+	ac.TLSClientConfig.CertData = cfg.TLSClientConfig.CertData
+	ac.TLSClientConfig.KeyData = cfg.TLSClientConfig.KeyData
+	if e := cfg.ExecProvider; e != nil {
+		ac.ExecProviderConfig = &argoExecProviderConfig{
+			Command:     e.Command,
+			Args:        e.Args,
+			APIVersion:  e.APIVersion,
+			InstallHint: e.InstallHint,
+		}
+		if len(e.Env) > 0 {
+			ac.ExecProviderConfig.Env = map[string]string{}
+			for _, ev := range e.Env {
+				ac.ExecProviderConfig.Env[ev.Name] = ev.Value
+			}
+		}
+	}
+
+	config, err := json.Marshal(ac) // map keys are sorted, so output is stable
+	if err != nil {
+		return "", nil, err
+	}
+	return cfg.Host, config, nil
+}
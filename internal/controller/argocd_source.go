@@ -0,0 +1,240 @@
+// internal/controller/argocd_source.go
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// argoSecretTypeLabel marks Argo CD cluster Secrets.
+	argoSecretTypeLabel = "argocd.argoproj.io/secret-type"
+
+	// generatedByLabel marks kubeconfig Secrets the controller synthesized itself; in the
+	// generating mode's target namespace they are RSIP sources regardless of --label-selector
+	// and namespace filters (see isTrustedGenerated).
+	generatedByLabel = "mirror.fluxcd.io/generatedBy"
+	generatedByArgo  = "argocd"
+)
+
+// argoClusterConfig is the subset of the Argo CD cluster "config" JSON we can translate.
+type argoClusterConfig struct {
+	Username        string `json:"username,omitempty"`
+	Password        string `json:"password,omitempty"`
+	BearerToken     string `json:"bearerToken,omitempty"`
+	TLSClientConfig struct {
+		Insecure   bool   `json:"insecure,omitempty"`
+		ServerName string `json:"serverName,omitempty"`
+		CAData     []byte `json:"caData,omitempty"`
+		CertData   []byte `json:"certData,omitempty"`
+		KeyData    []byte `json:"keyData,omitempty"`
+	} `json:"tlsClientConfig"`
+	ExecProviderConfig *argoExecProviderConfig `json:"execProviderConfig,omitempty"`
+	AWSAuthConfig      json.RawMessage         `json:"awsAuthConfig,omitempty"`
+}
+
+// argoExecProviderConfig mirrors Argo CD's execProviderConfig.
+type argoExecProviderConfig struct {
+	Command     string            `json:"command,omitempty"`
+	Args        []string          `json:"args,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	APIVersion  string            `json:"apiVersion,omitempty"`
+	InstallHint string            `json:"installHint,omitempty"`
+}
+
+// ArgoClusterReconciler turns Argo CD cluster Secrets into Flux kubeconfig Secrets
+// in the target namespace; the RSIP controller then picks those up like any other source.
+type ArgoClusterReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Opts      Options
+}
+
+func (r *ArgoClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("argocd").WithValues("secret", req.NamespacedName.String())
+
+	var src corev1.Secret
+	if err := r.Get(ctx, req.NamespacedName, &src); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.deleteGenerated(ctx, log, req.NamespacedName)
+	}
+	// skip Secrets we wrote ourselves with --argocd-output, or the two modes would feed each other
+	if src.Labels[argoSecretTypeLabel] != "cluster" || src.Labels[argoOutputLabel] != "" || !src.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, r.deleteGenerated(ctx, log, req.NamespacedName)
+	}
+
+	kubeconfig, err := argoKubeconfig(&src)
+	if err != nil {
+		// the kubeconfig generated before the change would keep the RSIP on stale credentials
+		r.Recorder.Eventf(&src, corev1.EventTypeWarning, "ArgoClusterUnsupported",
+			"cannot build a Flux kubeconfig, removing the generated one: %v", err)
+		log.Info("skipping Argo CD cluster secret", "reason", err.Error())
+		return reconcile.Result{}, r.deleteGenerated(ctx, log, req.NamespacedName)
+	}
+
+	name := string(src.Data["name"])
+	if name == "" {
+		name = src.Name
+	}
+
+	lbls := map[string]string{}
+	for _, k := range r.Opts.CopyLabelKeys {
+		if v, ok := src.Labels[k]; ok {
+			lbls[k] = v
+		}
+	}
+	for k, v := range src.Labels {
+		if hasAnyPrefix(r.Opts.CopyLabelPrefixes, k) {
+			lbls[k] = v
+		}
+	}
+	lbls[generatedByLabel] = generatedByArgo
+	lbls["mirror.fluxcd.io/argocdSecretNS"] = src.Namespace
+	lbls["mirror.fluxcd.io/argocdSecretName"] = src.Name
+	lbls[r.Opts.ClusterNameKey] = sanitizeDNS1123(name)
+
+	desired := &corev1.Secret{}
+	desired.SetNamespace(r.Opts.ArgoCDTargetNamespace)
+	desired.SetName(argoGeneratedSecretName(src.Name))
+	desired.SetLabels(lbls)
+	desired.SetAnnotations(map[string]string{
+		"mirror.fluxcd.io/argocdServer": string(src.Data["server"]),
+	})
+	desired.Type = corev1.SecretTypeOpaque
+	desired.Data = map[string][]byte{r.Opts.SecretKey: kubeconfig}
+
+	var existing corev1.Secret
+	err = r.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if client.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, err
+	}
+	if err != nil {
+		if err := r.Create(ctx, desired); err != nil {
+			r.Recorder.Eventf(&src, corev1.EventTypeWarning, "KubeconfigSecretCreateFailed",
+				"failed to create Secret %s/%s: %v", desired.Namespace, desired.Name, err)
+			return reconcile.Result{}, err
+		}
+		r.Recorder.Eventf(&src, corev1.EventTypeNormal, "KubeconfigSecretCreated",
+			"created Secret %s/%s", desired.Namespace, desired.Name)
+		log.Info("created kubeconfig secret", "name", desired.Name, "ns", desired.Namespace)
+		return reconcile.Result{}, nil
+	}
+
+	if maps.Equal(existing.Labels, desired.Labels) &&
+		maps.Equal(existing.Annotations, desired.Annotations) &&
+		maps.EqualFunc(existing.Data, desired.Data, func(a, b []byte) bool { return string(a) == string(b) }) {
+		log.V(1).Info("kubeconfig secret up-to-date", "name", desired.Name)
+		return reconcile.Result{}, nil
+	}
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Data = desired.Data
+	if err := r.Update(ctx, &existing); err != nil {
+		r.Recorder.Eventf(&src, corev1.EventTypeWarning, "KubeconfigSecretUpdateFailed",
+			"failed to update Secret %s/%s: %v", existing.Namespace, existing.Name, err)
+		return reconcile.Result{}, err
+	}
+	r.Recorder.Eventf(&src, corev1.EventTypeNormal, "KubeconfigSecretUpdated",
+		"updated Secret %s/%s", existing.Namespace, existing.Name)
+	log.Info("updated kubeconfig secret", "name", existing.Name)
+	return reconcile.Result{}, nil
+}
+
+// deleteGenerated removes the kubeconfig Secret synthesized for an Argo CD cluster Secret.
+// Deleting it lets the RSIP controller clean up the RSIP as usual.
+func (r *ArgoClusterReconciler) deleteGenerated(ctx context.Context, log logr.Logger, src types.NamespacedName) error {
+	var list corev1.SecretList
+	if err := r.APIReader.List(ctx, &list,
+		client.InNamespace(r.Opts.ArgoCDTargetNamespace),
+		client.MatchingLabels{
+			generatedByLabel:                    generatedByArgo,
+			"mirror.fluxcd.io/argocdSecretNS":   src.Namespace,
+			"mirror.fluxcd.io/argocdSecretName": src.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("list generated secrets: %w", err)
+	}
+	for i := range list.Items {
+		if err := r.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted kubeconfig secret", "name", list.Items[i].Name)
+	}
+	return nil
+}
+
+// argoGeneratedSecretName is the name of the Flux kubeconfig Secret for an Argo CD cluster Secret.
+func argoGeneratedSecretName(argoSecret string) string {
+	name := "argocd-" + argoSecret
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return name
+}
+
+// argoKubeconfig translates an Argo CD cluster Secret (server + config JSON) into kubeconfig bytes.
+func argoKubeconfig(sec *corev1.Secret) ([]byte, error) {
+	server := string(sec.Data["server"])
+	if server == "" {
+		return nil, fmt.Errorf("missing server")
+	}
+	var cfg argoClusterConfig
+	if raw := sec.Data["config"]; len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, fmt.Errorf("parse config: %w", err)
+		}
+	}
+	if len(cfg.AWSAuthConfig) > 0 && string(cfg.AWSAuthConfig) != "null" {
+		return nil, fmt.Errorf("awsAuthConfig is not supported")
+	}
+
+	name := sanitizeDNS1123(string(sec.Data["name"]))
+	auth := &clientcmdapi.AuthInfo{
+		Token:                 cfg.BearerToken,
+		Username:              cfg.Username,
+		Password:              cfg.Password,
+		ClientCertificateData: cfg.TLSClientConfig.CertData,
+		ClientKeyData:         cfg.TLSClientConfig.KeyData,
+	}
+	if e := cfg.ExecProviderConfig; e != nil {
+		exec := &clientcmdapi.ExecConfig{
+			Command:         e.Command,
+			Args:            e.Args,
+			APIVersion:      e.APIVersion,
+			InstallHint:     e.InstallHint,
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		}
+		for _, k := range slices.Sorted(maps.Keys(e.Env)) { // stable output, no spurious updates
+			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: k, Value: e.Env[k]})
+		}
+		auth.Exec = exec
+	}
+
+	kc := clientcmdapi.NewConfig()
+	kc.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   server,
+		TLSServerName:            cfg.TLSClientConfig.ServerName,
+		InsecureSkipTLSVerify:    cfg.TLSClientConfig.Insecure,
+		CertificateAuthorityData: cfg.TLSClientConfig.CAData,
+	}
+	kc.AuthInfos[name] = auth
+	kc.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	kc.CurrentContext = name
+	return clientcmd.Write(*kc)
+}
@@ -0,0 +1,226 @@
+// internal/controller/capabilities.go
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// capabilityLabelPrefix is the label domain used for discovered capabilities.
+const capabilityLabelPrefix = "capability.fcg.io/"
+
+// capabilityResult holds the labels and defaultValues derived from one discovery run.
+type capabilityResult struct {
+	Labels map[string]string
+	Values map[string]string
+}
+
+// capabilityDiscoverer runs API discovery against every selected cluster and maps
+// configured API groups/CRDs, GPU nodes and the Kubernetes minor version to RSIP labels.
+type capabilityDiscoverer struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Opts     Options
+
+	matches func(client.Object) bool
+	notify  func(types.NamespacedName)
+
+	mu      sync.RWMutex
+	results map[types.NamespacedName]capabilityResult
+}
+
+func newCapabilityDiscoverer(c client.Client, rec record.EventRecorder, opts Options,
+	matches func(client.Object) bool, notify func(types.NamespacedName)) *capabilityDiscoverer {
+	return &capabilityDiscoverer{
+		Client:   c,
+		Recorder: rec,
+		Opts:     opts,
+		matches:  matches,
+		notify:   notify,
+		results:  map[types.NamespacedName]capabilityResult{},
+	}
+}
+
+// Start implements manager.Runnable.
+func (d *capabilityDiscoverer) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("capabilities")
+	runEvery(ctx, d.Opts.CapabilityInterval, func(ctx context.Context) {
+		if err := d.discoverAll(ctx, log); err != nil {
+			log.Error(err, "capability discovery round failed")
+		}
+	})
+	return nil
+}
+
+// apply merges the last discovered capabilities for nn into the RSIP labels and defaultValues.
+func (d *capabilityDiscoverer) apply(nn types.NamespacedName, lbls map[string]string, dv map[string]any) {
+	d.mu.RLock()
+	res, ok := d.results[nn]
+	d.mu.RUnlock()
+	if !ok {
+		return
+	}
+	maps.Copy(lbls, res.Labels)
+	for k, v := range res.Values {
+		dv[k] = v
+	}
+}
+
+func (d *capabilityDiscoverer) discoverAll(ctx context.Context, log logr.Logger) error {
+	seen, err := forEachCluster(ctx, d.Client, d.Opts, d.matches, func(ctx context.Context, sec *corev1.Secret) {
+		d.discoverOne(ctx, log, sec)
+	})
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	for nn := range d.results {
+		if _, ok := seen[nn]; !ok {
+			delete(d.results, nn)
+		}
+	}
+	d.mu.Unlock()
+	return nil
+}
+
+func (d *capabilityDiscoverer) discoverOne(ctx context.Context, log logr.Logger, sec *corev1.Secret) {
+	nn := client.ObjectKeyFromObject(sec)
+
+	cfg, err := restConfigFromSecret(sec, kubeconfigKey(sec, d.Opts))
+	if err == nil {
+		var res capabilityResult
+		res, err = discoverCapabilities(ctx, cfg, d.Opts.Capabilities, d.Opts.CapabilityGPUResource)
+		if len(res.Labels) > 0 {
+			d.mu.Lock()
+			prev, hadPrev := d.results[nn]
+			if err != nil && hadPrev {
+				// a partial run only overrides what it determined; the rest keeps its last value
+				res = mergeCapabilities(prev, res)
+			}
+			d.results[nn] = res
+			d.mu.Unlock()
+			if !hadPrev || !maps.Equal(prev.Labels, res.Labels) || !maps.Equal(prev.Values, res.Values) {
+				log.V(1).Info("capabilities changed", "secret", nn.String(), "labels", res.Labels)
+				d.notify(nn)
+			}
+		}
+	}
+	if err != nil {
+		// keep the last known capabilities; a transient failure should not flap labels
+		d.Recorder.Eventf(sec, corev1.EventTypeWarning, "CapabilityDiscoveryFailed",
+			"capability discovery failed: %v", err)
+		log.Info("capability discovery failed", "secret", nn.String(), "error", err.Error())
+	}
+}
+
+// mergeCapabilities returns prev overlaid with the labels and values of partial.
+func mergeCapabilities(prev, partial capabilityResult) capabilityResult {
+	out := capabilityResult{Labels: maps.Clone(prev.Labels), Values: maps.Clone(prev.Values)}
+	maps.Copy(out.Labels, partial.Labels)
+	maps.Copy(out.Values, partial.Values)
+	return out
+}
+
+// discoverCapabilities checks each configured "group" or "group/resource" against the
+// cluster's discovery API and, if gpuResource is set, whether any node advertises it.
+// It returns whatever it could determine together with the joined errors.
+func discoverCapabilities(ctx context.Context, cfg *rest.Config, checks map[string]string, gpuResource string) (capabilityResult, error) {
+	res := capabilityResult{Labels: map[string]string{}, Values: map[string]string{}}
+	cfg = rest.CopyConfig(cfg)
+	cfg.Timeout = remoteTimeout
+
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return res, fmt.Errorf("discovery client: %w", err)
+	}
+
+	var errs []error
+	if info, err := dc.ServerVersion(); err != nil {
+		errs = append(errs, fmt.Errorf("server version: %w", err))
+	} else if minor := strings.TrimRight(info.Minor, "+"); minor != "" {
+		res.Labels[capabilityLabelPrefix+"kubernetes-minor"] = sanitizeLabelValue(minor)
+		res.Values["kubernetesMinor"] = minor
+	}
+
+	if len(checks) > 0 {
+		groups, err := dc.ServerGroups()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("server groups: %w", err))
+		} else {
+			preferred := map[string]string{}
+			for _, g := range groups.Groups {
+				preferred[g.Name] = g.PreferredVersion.GroupVersion
+			}
+			for name, target := range checks {
+				group, resource, _ := strings.Cut(target, "/")
+				gv, found := preferred[group]
+				if found && resource != "" {
+					found, err = hasResource(dc, gv, resource)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("capability %s: %w", name, err))
+						continue
+					}
+				}
+				setCapability(res, name, found)
+			}
+		}
+	}
+
+	if gpuResource != "" {
+		cs, err := kubernetes.NewForConfig(cfg)
+		if err == nil {
+			var nodes *corev1.NodeList
+			nodes, err = cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			if err == nil {
+				found := false
+				for i := range nodes.Items {
+					if q, ok := nodes.Items[i].Status.Allocatable[corev1.ResourceName(gpuResource)]; ok && !q.IsZero() {
+						found = true
+						break
+					}
+				}
+				setCapability(res, "gpu", found)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("gpu nodes: %w", err))
+		}
+	}
+
+	return res, errors.Join(errs...)
+}
+
+func hasResource(dc discovery.DiscoveryInterface, groupVersion, resource string) (bool, error) {
+	list, err := dc.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range list.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func setCapability(res capabilityResult, name string, found bool) {
+	res.Labels[capabilityLabelPrefix+name] = strconv.FormatBool(found)
+	res.Values[toCamel("capability-"+name)] = strconv.FormatBool(found)
+}
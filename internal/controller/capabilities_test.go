@@ -0,0 +1,90 @@
+// internal/controller/capabilities_test.go
+package controller
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestMergeCapabilities(t *testing.T) {
+	prev := capabilityResult{
+		Labels: map[string]string{
+			capabilityLabelPrefix + "kubernetes-minor": "29",
+			capabilityLabelPrefix + "gateway-api":      "true",
+			capabilityLabelPrefix + "gpu":              "false",
+		},
+		Values: map[string]string{"kubernetesMinor": "29", "capabilityGatewayApi": "true", "capabilityGpu": "false"},
+	}
+	tests := []struct {
+		name       string
+		partial    capabilityResult
+		wantLabels map[string]string
+		wantValues map[string]string
+	}{
+		{
+			name:       "empty partial keeps everything",
+			partial:    capabilityResult{},
+			wantLabels: prev.Labels,
+			wantValues: prev.Values,
+		},
+		{
+			name: "determined checks override, failed ones keep their last value",
+			partial: capabilityResult{
+				Labels: map[string]string{capabilityLabelPrefix + "kubernetes-minor": "30"},
+				Values: map[string]string{"kubernetesMinor": "30"},
+			},
+			wantLabels: map[string]string{
+				capabilityLabelPrefix + "kubernetes-minor": "30",
+				capabilityLabelPrefix + "gateway-api":      "true",
+				capabilityLabelPrefix + "gpu":              "false",
+			},
+			wantValues: map[string]string{"kubernetesMinor": "30", "capabilityGatewayApi": "true", "capabilityGpu": "false"},
+		},
+		{
+			name: "a flipped capability wins over the previous value",
+			partial: capabilityResult{
+				Labels: map[string]string{capabilityLabelPrefix + "gpu": "true"},
+				Values: map[string]string{"capabilityGpu": "true"},
+			},
+			wantLabels: map[string]string{
+				capabilityLabelPrefix + "kubernetes-minor": "29",
+				capabilityLabelPrefix + "gateway-api":      "true",
+				capabilityLabelPrefix + "gpu":              "true",
+			},
+			wantValues: map[string]string{"kubernetesMinor": "29", "capabilityGatewayApi": "true", "capabilityGpu": "true"},
+		},
+		{
+			name: "new capability is added",
+			partial: capabilityResult{
+				Labels: map[string]string{capabilityLabelPrefix + "istio": "true"},
+				Values: map[string]string{"capabilityIstio": "true"},
+			},
+			wantLabels: map[string]string{
+				capabilityLabelPrefix + "kubernetes-minor": "29",
+				capabilityLabelPrefix + "gateway-api":      "true",
+				capabilityLabelPrefix + "gpu":              "false",
+				capabilityLabelPrefix + "istio":            "true",
+			},
+			wantValues: map[string]string{
+				"kubernetesMinor": "29", "capabilityGatewayApi": "true", "capabilityGpu": "false", "capabilityIstio": "true",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := capabilityResult{Labels: maps.Clone(prev.Labels), Values: maps.Clone(prev.Values)}
+
+			got := mergeCapabilities(prev, tt.partial)
+			if !maps.Equal(got.Labels, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", got.Labels, tt.wantLabels)
+			}
+			if !maps.Equal(got.Values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", got.Values, tt.wantValues)
+			}
+			// the stored previous result must not change under readers
+			if !maps.Equal(prev.Labels, before.Labels) || !maps.Equal(prev.Values, before.Values) {
+				t.Errorf("previous result was modified: %v %v", prev.Labels, prev.Values)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// internal/controller/capi_source.go
+package controller
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var capiClusterGVK = schema.GroupVersionKind{
+	Group:   "cluster.x-k8s.io",
+	Version: "v1beta1",
+	Kind:    "Cluster",
+}
+
+const (
+	// capiClusterNameLabel is set by Cluster API on the <cluster>-kubeconfig Secret.
+	capiClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+	// capiKubeconfigKey is the data key Cluster API stores the kubeconfig under.
+	capiKubeconfigKey = "value"
+)
+
+// capiClusterInfo is what the RSIP takes from a Cluster API Cluster.
+type capiClusterInfo struct {
+	Name               string
+	Labels             map[string]string
+	Ready              bool // ControlPlaneReady
+	TopologyVersion    string
+	InfrastructureKind string
+}
+
+// isCAPIKubeconfig reports whether obj is the kubeconfig Secret Cluster API writes for a Cluster.
+func isCAPIKubeconfig(obj client.Object) bool {
+	cluster := obj.GetLabels()[capiClusterNameLabel]
+	return cluster != "" && obj.GetName() == cluster+"-kubeconfig"
+}
+
+// kubeconfigKey returns the data key holding the kubeconfig for a source Secret.
+func kubeconfigKey(obj client.Object, opts Options) string {
+	if opts.CAPISourceEnabled && isCAPIKubeconfig(obj) {
+		return capiKubeconfigKey
+	}
+	return opts.SecretKey
+}
+
+// getCAPICluster reads the Cluster that owns a kubeconfig Secret. found is false if it does not exist.
+func getCAPICluster(ctx context.Context, c client.Reader, ns, name string) (info capiClusterInfo, found bool, err error) {
+	var u unstructured.Unstructured
+	u.SetGroupVersionKind(capiClusterGVK)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &u); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return info, false, nil
+		}
+		return info, false, err
+	}
+	return capiClusterInfoFrom(&u), true, nil
+}
+
+func capiClusterInfoFrom(u *unstructured.Unstructured) capiClusterInfo {
+	info := capiClusterInfo{Name: u.GetName(), Labels: u.GetLabels()}
+	info.TopologyVersion, _, _ = unstructured.NestedString(u.Object, "spec", "topology", "version")
+	info.InfrastructureKind, _, _ = unstructured.NestedString(u.Object, "spec", "infrastructureRef", "kind")
+
+	// v1beta1 exposes both a boolean and a condition; accept either.
+	info.Ready, _, _ = unstructured.NestedBool(u.Object, "status", "controlPlaneReady")
+	if !info.Ready {
+		info.Ready = hasTrueCondition(u, "ControlPlaneReady")
+	}
+	return info
+}
+
+// hasTrueCondition reports whether status.conditions has condType with status "True".
+func hasTrueCondition(u *unstructured.Unstructured, condType string) bool {
+	conds, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if m["type"] == condType {
+			return strings.EqualFold(toString(m["status"]), "true")
+		}
+	}
+	return false
+}
+
+func toString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// capiClusterToSecret maps a Cluster event to its kubeconfig Secret.
+func capiClusterToSecret(_ context.Context, obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName() + "-kubeconfig",
+	}}}
+}
@@ -0,0 +1,224 @@
+// internal/controller/cluster_state.go
+package controller
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// rsipView is a generated RSIP as the controller last wrote it.
+type rsipView struct {
+	Namespace     string            `json:"namespace"`
+	Name          string            `json:"name"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	DefaultValues map[string]any    `json:"defaultValues,omitempty"`
+}
+
+// clusterView is what the controller knows about one source: the RSIPs generated for it, or
+// why it was skipped. It never contains kubeconfig data, only the Secret's name.
+type clusterView struct {
+	Name       string     `json:"name"`
+	Project    string     `json:"project,omitempty"`
+	Source     string     `json:"source"`
+	Server     string     `json:"server,omitempty"`
+	SkipReason string     `json:"skipReason,omitempty"`
+	RSIPs      []rsipView `json:"rsips,omitempty"`
+	Updated    time.Time  `json:"updated"`
+}
+
+// sourceState is the mutable record behind a clusterView.
+type sourceState struct {
+	name       string
+	project    string
+	labels     map[string]string // source labels, used for selectors while skipped
+	server     string            // API server URL, when the source has one
+	skipReason string
+	rsips      map[types.NamespacedName]rsipView
+	updated    time.Time
+}
+
+// clusterState is the reconcilers' in-memory view of the fleet, keyed by source reference
+// (see rsipSource). A nil *clusterState ignores all updates.
+type clusterState struct {
+	mu      sync.RWMutex
+	sources map[string]*sourceState
+}
+
+func newClusterState() *clusterState {
+	return &clusterState{sources: map[string]*sourceState{}}
+}
+
+func (s *clusterState) source(key string) *sourceState {
+	st := s.sources[key]
+	if st == nil {
+		st = &sourceState{rsips: map[types.NamespacedName]rsipView{}}
+		s.sources[key] = st
+	}
+	return st
+}
+
+// applied records an RSIP written for its source.
+func (s *clusterState) applied(desired *unstructured.Unstructured) {
+	if s == nil {
+		return
+	}
+	lbls := desired.GetLabels()
+	key := rsipSource(lbls)
+	if key == "" {
+		return
+	}
+	dv, _, _ := unstructured.NestedMap(desired.Object, "spec", "defaultValues")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.source(key)
+	nn := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+	cur, ok := st.rsips[nn]
+	if ok && st.skipReason == "" && maps.Equal(cur.Labels, lbls) && mapsEqual(cur.DefaultValues, dv) {
+		return // unchanged: keep Updated (and the API's ETags) stable across resyncs
+	}
+	st.name = lbls["mirror.fluxcd.io/clusterName"]
+	st.project = lbls["mirror.fluxcd.io/project"]
+	st.skipReason = ""
+	st.rsips[nn] = rsipView{
+		Namespace:     nn.Namespace,
+		Name:          nn.Name,
+		Labels:        maps.Clone(lbls),
+		DefaultValues: dv,
+	}
+	st.updated = time.Now()
+}
+
+// retain forgets the RSIPs of the source identified by lbls other than keep.
+func (s *clusterState) retain(lbls map[string]string, keep ...types.NamespacedName) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.sources[rsipSource(lbls)]
+	if !ok {
+		return
+	}
+	for nn := range st.rsips {
+		if !slices.Contains(keep, nn) {
+			delete(st.rsips, nn)
+			st.updated = time.Now()
+		}
+	}
+}
+
+// deleted forgets one RSIP; a source left without RSIPs or a skip reason is forgotten too.
+func (s *clusterState) deleted(lbls map[string]string, nn types.NamespacedName) {
+	if s == nil {
+		return
+	}
+	key := rsipSource(lbls)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.sources[key]
+	if !ok {
+		return
+	}
+	delete(st.rsips, nn)
+	st.updated = time.Now()
+	if len(st.rsips) == 0 && st.skipReason == "" {
+		delete(s.sources, key)
+	}
+}
+
+// skipped records why a source has no RSIPs.
+func (s *clusterState) skipped(key, name string, lbls map[string]string, reason string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.source(key)
+	if st.skipReason == reason && len(st.rsips) == 0 {
+		return
+	}
+	if st.name == "" {
+		st.name = name
+	}
+	st.labels = maps.Clone(lbls)
+	st.skipReason = reason
+	clear(st.rsips)
+	st.updated = time.Now()
+}
+
+// setServer records the API server URL of the source identified by lbls.
+func (s *clusterState) setServer(lbls map[string]string, server string) {
+	if s == nil {
+		return
+	}
+	key := rsipSource(lbls)
+	if key == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := s.source(key)
+	if st.server != server {
+		st.server = server
+		st.updated = time.Now()
+	}
+}
+
+// forget drops a source that no longer exists.
+func (s *clusterState) forget(key string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sources, key)
+}
+
+// list returns the sources with an RSIP (or, when skipped, source labels) matching sel,
+// ordered by name and source. name, when set, selects a single cluster name.
+func (s *clusterState) list(name string, sel labels.Selector) []clusterView {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []clusterView{}
+	for key, st := range s.sources {
+		if name != "" && st.name != name {
+			continue
+		}
+		matched := len(st.rsips) == 0 && sel.Matches(labels.Set(st.labels))
+		v := clusterView{
+			Name:       st.name,
+			Project:    st.project,
+			Source:     key,
+			Server:     st.server,
+			SkipReason: st.skipReason,
+			Updated:    st.updated.UTC().Truncate(time.Second),
+		}
+		for _, r := range st.rsips {
+			matched = matched || sel.Matches(labels.Set(r.Labels))
+			v.RSIPs = append(v.RSIPs, r)
+		}
+		if !matched {
+			continue
+		}
+		slices.SortFunc(v.RSIPs, func(a, b rsipView) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+		out = append(out, v)
+	}
+	slices.SortFunc(out, func(a, b clusterView) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Source, b.Source))
+	})
+	return out
+}
+
+// secretSource is the rsipSource key of a kubeconfig Secret.
+func secretSource(nn types.NamespacedName) string {
+	return "Secret " + nn.Namespace + "/" + nn.Name
+}
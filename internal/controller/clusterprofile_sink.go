@@ -0,0 +1,198 @@
+// internal/controller/clusterprofile_sink.go
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// clusterProfileOutputLabel marks ClusterProfiles written by the controller.
+	clusterProfileOutputLabel = "mirror.fluxcd.io/clusterProfileOutput"
+	// clusterProfileManager is the clusterManager name on published ClusterProfiles.
+	clusterProfileManager = "flux-cluster-generator"
+	// clusterProfileCredentialProvider names the credential provider on published ClusterProfiles:
+	// consumers read the kubeconfig from the Secret named in the mirror.fluxcd.io/secret* labels.
+	clusterProfileCredentialProvider = "secretreader"
+)
+
+// clusterProfileWriter publishes a ClusterProfile for every qualifying kubeconfig Secret,
+// so other fleet tools see the same inventory as the RSIPs.
+type clusterProfileWriter struct {
+	Client    client.Client
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Opts      Options
+}
+
+// ensure creates or updates the ClusterProfile for sec. lbls are the RSIP labels; the ones
+// not in the mirror.fluxcd.io domain are also published as properties.
+func (w *clusterProfileWriter) ensure(ctx context.Context, sec *corev1.Secret, clusterName string, lbls map[string]string) error {
+	log := ctrl.Log.WithName("clusterprofile-output").WithValues("secret", client.ObjectKeyFromObject(sec).String())
+
+	cfg, err := restConfigFromSecret(sec, kubeconfigKey(sec, w.Opts))
+	if err != nil {
+		// a ClusterProfile written for the previous kubeconfig would keep advertising it
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterProfileSkipped",
+			"cannot read kubeconfig for ClusterProfile, removing it: %v", err)
+		log.Info("skipping ClusterProfile", "reason", err.Error())
+		return w.remove(ctx, client.ObjectKeyFromObject(sec))
+	}
+
+	desiredLabels := maps.Clone(lbls)
+	desiredLabels[clusterManagerLabel] = clusterProfileManager
+	desiredLabels[clusterProfileOutputLabel] = "true"
+
+	desired := &unstructured.Unstructured{}
+	desired.SetGroupVersionKind(clusterProfileGVK)
+	desired.SetNamespace(w.Opts.ClusterProfileOutputNamespace)
+	desired.SetName(clusterProfileOutputName(sec))
+	desired.SetLabels(desiredLabels)
+	_ = unstructured.SetNestedField(desired.Object, map[string]any{
+		"displayName":    clusterName,
+		"clusterManager": map[string]any{"name": clusterProfileManager},
+	}, "spec")
+
+	cluster := map[string]any{"server": cfg.Host}
+	if len(cfg.TLSClientConfig.CAData) > 0 {
+		cluster["certificate-authority-data"] = base64.StdEncoding.EncodeToString(cfg.TLSClientConfig.CAData)
+	}
+	if cfg.TLSClientConfig.ServerName != "" {
+		cluster["tls-server-name"] = cfg.TLSClientConfig.ServerName
+	}
+	var properties []any
+	for _, k := range slices.Sorted(maps.Keys(lbls)) {
+		if !strings.HasPrefix(k, "mirror.fluxcd.io/") {
+			properties = append(properties, map[string]any{"name": k, "value": lbls[k]})
+		}
+	}
+	desiredStatus := map[string]any{
+		"credentialProviders": []any{map[string]any{
+			"name":    clusterProfileCredentialProvider,
+			"cluster": cluster,
+		}},
+	}
+	if len(properties) > 0 {
+		desiredStatus["properties"] = properties
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(clusterProfileGVK)
+	err = w.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	if err != nil {
+		if err := w.Client.Create(ctx, desired); err != nil {
+			w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterProfileCreateFailed",
+				"failed to create ClusterProfile %s/%s: %v", desired.GetNamespace(), desired.GetName(), err)
+			return err
+		}
+		w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ClusterProfileCreated",
+			"created ClusterProfile %s/%s", desired.GetNamespace(), desired.GetName())
+		log.Info("created ClusterProfile", "name", desired.GetName(), "ns", desired.GetNamespace())
+		// the profile may have been written under an earlier name
+		if err := w.removeExcept(ctx, client.ObjectKeyFromObject(sec), desired.GetName()); err != nil {
+			return err
+		}
+		existing = desired
+	} else if xl := existing.GetLabels(); xl[clusterProfileOutputLabel] != "true" ||
+		xl["mirror.fluxcd.io/secretNS"] != sec.Namespace || xl["mirror.fluxcd.io/secretName"] != sec.Name {
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterProfileConflict",
+			"ClusterProfile %s/%s exists and was not written for this Secret; not overwriting it",
+			existing.GetNamespace(), existing.GetName())
+		return fmt.Errorf("ClusterProfile %s/%s exists and was not written for %s/%s",
+			existing.GetNamespace(), existing.GetName(), sec.Namespace, sec.Name)
+	} else {
+		curSpec, _, _ := unstructured.NestedMap(existing.Object, "spec")
+		desSpec, _, _ := unstructured.NestedMap(desired.Object, "spec")
+		if !maps.Equal(existing.GetLabels(), desiredLabels) || !mapsEqual(curSpec, desSpec) {
+			existing.SetLabels(desiredLabels)
+			_ = unstructured.SetNestedMap(existing.Object, desSpec, "spec")
+			if err := w.Client.Update(ctx, existing); err != nil {
+				w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterProfileUpdateFailed",
+					"failed to update ClusterProfile %s/%s: %v", existing.GetNamespace(), existing.GetName(), err)
+				return err
+			}
+			w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ClusterProfileUpdated",
+				"updated ClusterProfile %s/%s", existing.GetNamespace(), existing.GetName())
+			log.Info("updated ClusterProfile", "name", existing.GetName())
+		}
+	}
+
+	// credential providers and properties live in status (a subresource)
+	curStatus, _, _ := unstructured.NestedMap(existing.Object, "status")
+	merged := maps.Clone(curStatus)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+	delete(merged, "properties")
+	maps.Copy(merged, desiredStatus)
+	if mapsEqual(curStatus, merged) {
+		log.V(1).Info("ClusterProfile up-to-date", "name", existing.GetName())
+		return nil
+	}
+	_ = unstructured.SetNestedMap(existing.Object, merged, "status")
+	if err := w.Client.Status().Update(ctx, existing); err != nil {
+		return fmt.Errorf("update ClusterProfile %s/%s status: %w", existing.GetNamespace(), existing.GetName(), err)
+	}
+	log.V(1).Info("updated ClusterProfile status", "name", existing.GetName())
+	return nil
+}
+
+// remove deletes the ClusterProfiles written for a source Secret.
+func (w *clusterProfileWriter) remove(ctx context.Context, secretNN types.NamespacedName) error {
+	return w.removeExcept(ctx, secretNN, "")
+}
+
+// removeExcept deletes the ClusterProfiles written for a source Secret other than keep.
+func (w *clusterProfileWriter) removeExcept(ctx context.Context, secretNN types.NamespacedName, keep string) error {
+	log := ctrl.Log.WithName("clusterprofile-output")
+
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: clusterProfileGVK.Group, Version: clusterProfileGVK.Version, Kind: clusterProfileGVK.Kind + "List",
+	})
+	if err := w.APIReader.List(ctx, &list,
+		client.InNamespace(w.Opts.ClusterProfileOutputNamespace),
+		client.MatchingLabels{
+			clusterProfileOutputLabel:     "true",
+			"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
+			"mirror.fluxcd.io/secretName": secretNN.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("list ClusterProfiles: %w", err)
+	}
+	for i := range list.Items {
+		if list.Items[i].GetName() == keep {
+			continue
+		}
+		if err := w.Client.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted ClusterProfile", "name", list.Items[i].GetName(), "secret", secretNN.String())
+	}
+	return nil
+}
+
+// clusterProfileOutputName is the ClusterProfile name for a source Secret. The namespace is a
+// DNS label, so the dot keeps every namespace/name combination distinct.
+func clusterProfileOutputName(sec *corev1.Secret) string {
+	name := sec.Namespace + "." + sec.Name
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+// internal/controller/clusterprofile_source.go
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var clusterProfileGVK = schema.GroupVersionKind{
+	Group:   "multicluster.x-k8s.io",
+	Version: "v1alpha1",
+	Kind:    "ClusterProfile",
+}
+
+const (
+	// clusterManagerLabel names the tool that manages a ClusterProfile (set by the API convention).
+	clusterManagerLabel = "x-k8s.io/cluster-manager"
+	// propertyLabelPrefix is the label domain used for copied ClusterProfile properties.
+	propertyLabelPrefix = "property.fcg.io/"
+)
+
+// ClusterProfileReconciler generates RSIPs from SIG-Multicluster ClusterProfiles. The RSIP carries
+// the credential provider references instead of a kubeconfig Secret.
+type ClusterProfileReconciler struct {
+	*SecretMirrorReconciler
+}
+
+func (r *ClusterProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("rsip").WithValues("clusterProfile", req.NamespacedName.String())
+
+	var cp unstructured.Unstructured
+	cp.SetGroupVersionKind(clusterProfileGVK)
+	if err := r.Get(ctx, req.NamespacedName, &cp); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.ensureClusterProfileRSIPAbsence(ctx, req.NamespacedName)
+	}
+
+	// filters (profiles we publish ourselves are never read back)
+	if cp.GetLabels()[clusterProfileOutputLabel] != "" || !r.allowedNS.Has(cp.GetNamespace()) {
+		log.V(1).Info("cluster profile not selected; ensuring cleanup")
+		return reconcile.Result{}, r.ensureClusterProfileRSIPAbsence(ctx, req.NamespacedName)
+	}
+	providers, server := clusterProfileCredentialProviders(&cp)
+	if len(providers) == 0 {
+		r.Recorder.Eventf(&cp, corev1.EventTypeWarning, "NoCredentialProviders",
+			"cluster profile has no status.credentialProviders; no RSIP generated")
+		log.Info("cluster profile has no credential providers; ensuring cleanup")
+		return reconcile.Result{}, r.ensureClusterProfileRSIPAbsence(ctx, req.NamespacedName)
+	}
+
+	displayName, _, _ := unstructured.NestedString(cp.Object, "spec", "displayName")
+	manager, _, _ := unstructured.NestedString(cp.Object, "spec", "clusterManager", "name")
+	refValues := map[string]any{
+		"clusterProfileName":  cp.GetName(),
+		"clusterProfileNS":    cp.GetNamespace(),
+		"credentialProviders": providers,
+	}
+	if server != "" {
+		refValues["server"] = server
+	}
+	if manager != "" {
+		refValues["clusterManager"] = manager
+	}
+	rsip, _, lbls, dv := r.rsipSkeleton(log, rsipInput{
+		Name:        cp.GetName(),
+		Namespace:   cp.GetNamespace(),
+		Labels:      cp.GetLabels(),
+		Annotations: cp.GetAnnotations(),
+		ClusterName: displayName,
+		RefLabels: map[string]string{
+			"mirror.fluxcd.io/clusterProfileNS":   cp.GetNamespace(),
+			"mirror.fluxcd.io/clusterProfileName": cp.GetName(),
+		},
+		RefValues: refValues,
+	})
+	if v, _, _ := unstructured.NestedString(cp.Object, "status", "version", "kubernetes"); v != "" {
+		lbls["mirror.fluxcd.io/kubernetesVersion"] = sanitizeLabelValue(v)
+		dv["kubernetesVersion"] = v
+	}
+	applyNamedValues(lbls, dv, propertyLabelPrefix, "property-", nameValues(&cp, "status", "properties"))
+
+	desired := newRSIP(rsip.Namespace, rsip.Name, lbls, dv)
+	if err := r.applyRSIP(ctx, log, &cp, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.State.setServer(lbls, server)
+	return reconcile.Result{}, r.pruneRSIPs(ctx, log, lbls, rsip)
+}
+
+func (r *ClusterProfileReconciler) ensureClusterProfileRSIPAbsence(ctx context.Context, nn types.NamespacedName) error {
+	return r.deleteRSIPsFor(ctx, "clusterProfile", nn, labels.SelectorFromSet(labels.Set{
+		"mirror.fluxcd.io/clusterProfileNS":   nn.Namespace,
+		"mirror.fluxcd.io/clusterProfileName": nn.Name,
+	}))
+}
+
+// clusterProfileCredentialProviders returns the names in status.credentialProviders and the
+// first API server address they advertise.
+func clusterProfileCredentialProviders(cp *unstructured.Unstructured) (names []any, server string) {
+	items, _, _ := unstructured.NestedSlice(cp.Object, "status", "credentialProviders")
+	for _, it := range items {
+		m, ok := it.(map[string]any)
+		if !ok {
+			continue
+		}
+		name := toString(m["name"])
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+		if server == "" {
+			server, _, _ = unstructured.NestedString(m, "cluster", "server")
+		}
+	}
+	return names, server
+}
@@ -0,0 +1,255 @@
+// internal/controller/cmdb_source.go
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// cmdbEntryLabel links an RSIP to the CMDB entry it was generated for (--cmdb-generate).
+	cmdbEntryLabel = "mirror.fluxcd.io/cmdbEntry"
+	// cmdbLabelPrefix is the label domain used for CMDB attributes.
+	cmdbLabelPrefix = "cmdb.fcg.io/"
+	// cmdbMaxBody bounds the CMDB response size.
+	cmdbMaxBody = 16 << 20
+)
+
+// cmdbCluster is one element of the JSON list served by the CMDB.
+type cmdbCluster struct {
+	Name       string            `json:"name"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	SecretRef  *inventorySecret  `json:"secretRef,omitempty"` // only used with --cmdb-generate
+}
+
+// cmdbPoller fetches the cluster list from --cmdb-url and merges each cluster's attributes
+// into the RSIP with the same cluster name. With --cmdb-generate, entries that carry a
+// secretRef also become RSIPs of their own.
+type cmdbPoller struct {
+	HTTP      *http.Client
+	APIReader client.Reader
+	Opts      Options
+	Entries   *entrySet // nil unless --cmdb-generate
+
+	requeue func(rsipLabels map[string]string)
+
+	mu    sync.RWMutex
+	etag  string
+	attrs map[string]map[string]string // cluster name -> attributes
+}
+
+func newCMDBPoller(r client.Reader, opts Options, requeue func(map[string]string)) *cmdbPoller {
+	p := &cmdbPoller{
+		HTTP:      &http.Client{Timeout: remoteTimeout},
+		APIReader: r,
+		Opts:      opts,
+		requeue:   requeue,
+		attrs:     map[string]map[string]string{},
+	}
+	if opts.CMDBGenerate {
+		p.Entries = newEntrySet(cmdbEntryLabel)
+	}
+	return p
+}
+
+// Start implements manager.Runnable.
+func (p *cmdbPoller) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("cmdb").WithValues("url", p.Opts.CMDBURL)
+	staleChecked := false
+	runEvery(ctx, p.Opts.CMDBInterval, func(ctx context.Context) {
+		if err := p.poll(ctx, log); err != nil {
+			log.Error(err, "cmdb poll failed; keeping previous data")
+			return
+		}
+		// only after a successful first fetch, or every generated RSIP would look stale
+		if p.Entries != nil && !staleChecked {
+			if err := p.Entries.enqueueStale(ctx, p.APIReader); err != nil {
+				log.Error(err, "listing cmdb RSIPs failed")
+				return
+			}
+			staleChecked = true
+		}
+	})
+	return nil
+}
+
+// apply merges the CMDB attributes for clusterName into the RSIP labels and defaultValues.
+// The CMDB wins over copied labels but never over the values identifying the cluster (reserved).
+func (p *cmdbPoller) apply(clusterName string, lbls map[string]string, dv map[string]any, reserved sets.Set[string]) {
+	p.mu.RLock()
+	attrs, ok := p.attrs[clusterName]
+	p.mu.RUnlock()
+	if !ok {
+		return
+	}
+	for k, v := range attrs {
+		if lv := sanitizeLabelValue(v); lv != "" {
+			lbls[cmdbLabelPrefix+k] = lv
+		}
+		if ck := toCamel(k); !reserved.Has(ck) {
+			dv[ck] = v
+		}
+	}
+}
+
+func (p *cmdbPoller) poll(ctx context.Context, log logr.Logger) error {
+	clusters, modified, err := p.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if !modified {
+		log.V(1).Info("cmdb not modified")
+		return nil
+	}
+
+	next := make(map[string]map[string]string, len(clusters))
+	for _, c := range clusters {
+		next[c.Name] = c.Attributes
+	}
+	p.mu.Lock()
+	prev := p.attrs
+	p.attrs = next
+	p.mu.Unlock()
+
+	changed := 0
+	for name := range sets.KeySet(prev).Union(sets.KeySet(next)) {
+		a, inPrev := prev[name]
+		b, inNext := next[name]
+		if inPrev != inNext || !maps.Equal(a, b) {
+			changed++
+			if err := p.requeueCluster(ctx, name); err != nil {
+				log.Error(err, "requeue after cmdb change failed", "cluster", name)
+			}
+		}
+	}
+
+	if p.Entries != nil {
+		entries := map[string]inventoryEntry{}
+		for _, c := range clusters {
+			if c.SecretRef != nil {
+				entries[c.Name] = inventoryEntry{Name: c.Name, SecretRef: *c.SecretRef}
+			}
+		}
+		p.Entries.replace(entries)
+	}
+	log.Info("cmdb loaded", "clusters", len(clusters), "changed", changed)
+	return nil
+}
+
+// requeueCluster re-reconciles the sources behind the RSIPs of a cluster whose attributes changed.
+func (p *cmdbPoller) requeueCluster(ctx context.Context, clusterName string) error {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := p.APIReader.List(ctx, &list,
+		managedRSIPs(labels.SelectorFromSet(labels.Set{"mirror.fluxcd.io/clusterName": clusterName})),
+	); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		p.requeue(list.Items[i].GetLabels())
+	}
+	return nil
+}
+
+// fetch GETs --cmdb-url, honoring the ETag of the last successful response. modified is
+// false on 304 Not Modified.
+func (p *cmdbPoller) fetch(ctx context.Context) (clusters []cmdbCluster, modified bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Opts.CMDBURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.Opts.CMDBBearerTokenFile != "" {
+		// read on every poll so a rotated (mounted) token is picked up
+		token, err := os.ReadFile(p.Opts.CMDBBearerTokenFile)
+		if err != nil {
+			return nil, false, fmt.Errorf("read bearer token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	p.mu.RLock()
+	etag := p.etag
+	p.mu.RUnlock()
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := p.HTTP.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, false, nil
+	case http.StatusOK:
+	default:
+		return nil, false, fmt.Errorf("GET %s: unexpected status %s", p.Opts.CMDBURL, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, cmdbMaxBody))
+	if err != nil {
+		return nil, false, err
+	}
+	clusters, err = parseCMDB(body)
+	if err != nil {
+		return nil, false, err
+	}
+	// remember the ETag only once the body was accepted
+	p.mu.Lock()
+	p.etag = resp.Header.Get("ETag")
+	p.mu.Unlock()
+	return clusters, true, nil
+}
+
+// parseCMDB strictly decodes and validates the CMDB response: a JSON list of clusters.
+func parseCMDB(body []byte) ([]cmdbCluster, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	var clusters []cmdbCluster
+	if err := dec.Decode(&clusters); err != nil {
+		return nil, fmt.Errorf("decode cmdb response: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("decode cmdb response: unexpected data after the cluster list")
+	}
+
+	seen := sets.New[string]()
+	for i, c := range clusters {
+		if errs := validation.IsDNS1123Label(c.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("cmdb cluster %d: invalid name %q: %v", i, c.Name, errs)
+		}
+		if seen.Has(c.Name) {
+			return nil, fmt.Errorf("cmdb cluster %d: duplicate name %q", i, c.Name)
+		}
+		seen.Insert(c.Name)
+		for k := range c.Attributes {
+			if errs := validation.IsQualifiedName(cmdbLabelPrefix + k); len(errs) > 0 {
+				return nil, fmt.Errorf("cmdb cluster %q: invalid attribute %q: %v", c.Name, k, errs)
+			}
+		}
+		if c.SecretRef != nil && c.SecretRef.Name == "" {
+			return nil, fmt.Errorf("cmdb cluster %q: secretRef.name is required", c.Name)
+		}
+	}
+	return clusters, nil
+}
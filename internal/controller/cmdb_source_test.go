@@ -0,0 +1,161 @@
+// internal/controller/cmdb_source_test.go
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// noRSIPs is a client.Reader without any objects, so requeueCluster finds nothing to requeue.
+type noRSIPs struct{}
+
+func (noRSIPs) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return nil
+}
+
+func (noRSIPs) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+// fakeCMDB serves a settable response and honors If-None-Match.
+type fakeCMDB struct {
+	mu     sync.Mutex
+	status int
+	etag   string
+	body   string
+	notMod int
+}
+
+func (f *fakeCMDB) set(status int, etag, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status, f.etag, f.body = status, etag, body
+}
+
+func (f *fakeCMDB) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.etag != "" && r.Header.Get("If-None-Match") == f.etag {
+		f.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if f.etag != "" {
+		w.Header().Set("ETag", f.etag)
+	}
+	w.WriteHeader(f.status)
+	_, _ = w.Write([]byte(f.body))
+}
+
+func newTestCMDBPoller(t *testing.T, f *fakeCMDB) *cmdbPoller {
+	t.Helper()
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return newCMDBPoller(noRSIPs{}, Options{CMDBURL: srv.URL}, func(map[string]string) {})
+}
+
+const cmdbV1 = `[{"name":"dev-1","attributes":{"region":"eu-west-1"}}]`
+
+func TestCMDBPollETag(t *testing.T) {
+	f := &fakeCMDB{}
+	f.set(http.StatusOK, `"v1"`, cmdbV1)
+	p := newTestCMDBPoller(t, f)
+
+	if err := p.poll(context.Background(), logr.Discard()); err != nil {
+		t.Fatalf("first poll: %v", err)
+	}
+	if got := p.attrs["dev-1"]["region"]; got != "eu-west-1" {
+		t.Fatalf("region = %q, want eu-west-1", got)
+	}
+	if p.etag != `"v1"` {
+		t.Fatalf("etag = %q, want \"v1\"", p.etag)
+	}
+
+	// same ETag: the server answers 304 and the entries stay
+	if err := p.poll(context.Background(), logr.Discard()); err != nil {
+		t.Fatalf("second poll: %v", err)
+	}
+	if f.notMod != 1 {
+		t.Fatalf("304 responses = %d, want 1", f.notMod)
+	}
+	if got := p.attrs["dev-1"]["region"]; got != "eu-west-1" {
+		t.Fatalf("region after 304 = %q, want eu-west-1", got)
+	}
+}
+
+func TestCMDBPollKeepsPreviousData(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{name: "malformed body", status: http.StatusOK, body: `[{"name":`, wantErr: "decode cmdb response"},
+		{name: "unknown field", status: http.StatusOK, body: `[{"name":"dev-1","owner":"x"}]`, wantErr: "unknown field"},
+		{name: "server error", status: http.StatusInternalServerError, body: "boom", wantErr: "unexpected status"},
+		{name: "not found", status: http.StatusNotFound, wantErr: "unexpected status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeCMDB{}
+			f.set(http.StatusOK, `"v1"`, cmdbV1)
+			p := newTestCMDBPoller(t, f)
+			if err := p.poll(context.Background(), logr.Discard()); err != nil {
+				t.Fatalf("first poll: %v", err)
+			}
+
+			f.set(tt.status, `"v2"`, tt.body)
+			err := p.poll(context.Background(), logr.Discard())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+			if got := p.attrs["dev-1"]["region"]; got != "eu-west-1" {
+				t.Errorf("region = %q, want the previous eu-west-1", got)
+			}
+			if p.etag != `"v1"` {
+				t.Errorf("etag = %q, want the previous \"v1\"", p.etag)
+			}
+		})
+	}
+}
+
+func TestParseCMDB(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr string
+	}{
+		{name: "valid", body: `[{"name":"a"},{"name":"b","attributes":{"tier":"edge"},"secretRef":{"name":"b-kubeconfig"}}]`, want: 2},
+		{name: "empty list", body: `[]`, want: 0},
+		{name: "trailing data", body: `[] []`, wantErr: "unexpected data"},
+		{name: "invalid name", body: `[{"name":"Not_A_Label"}]`, wantErr: "invalid name"},
+		{name: "duplicate name", body: `[{"name":"a"},{"name":"a"}]`, wantErr: "duplicate name"},
+		{name: "invalid attribute", body: `[{"name":"a","attributes":{"bad key":"x"}}]`, wantErr: "invalid attribute"},
+		{name: "secretRef without name", body: `[{"name":"a","secretRef":{}}]`, wantErr: "secretRef.name is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusters, err := parseCMDB([]byte(tt.body))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(clusters) != tt.want {
+				t.Fatalf("clusters = %d, want %d", len(clusters), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// internal/controller/configmap_source.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Providers Flux accepts in a kubeConfig.configMapRef ConfigMap.
+var kubeConfigMapProviders = map[string]bool{
+	"aws":     true,
+	"azure":   true,
+	"gcp":     true,
+	"generic": true,
+}
+
+// KubeConfigMapReconciler generates RSIPs from Flux workload-identity kubeconfig ConfigMaps
+// (provider/cluster/address), reusing the Secret pipeline for naming, label-copy and apply.
+type KubeConfigMapReconciler struct {
+	*SecretMirrorReconciler
+}
+
+func (r *KubeConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("rsip").WithValues("configmap", req.NamespacedName.String())
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, req.NamespacedName, &cm); err != nil {
+		if err2 := r.ensureConfigMapRSIPAbsence(ctx, req.NamespacedName); err2 != nil {
+			log.Error(err2, "cleanup after configmap deletion failed")
+			return reconcile.Result{}, err2
+		}
+		return reconcile.Result{}, nil
+	}
+
+	// filters
+	if !r.allowedNS.Has(cm.Namespace) || !r.Opts.ConfigMapSelector.Matches(labels.Set(cm.Labels)) {
+		log.V(1).Info("configmap no longer selected; ensuring cleanup")
+		return reconcile.Result{}, r.ensureConfigMapRSIPAbsence(ctx, req.NamespacedName)
+	}
+	provider, err := validateKubeConfigMap(&cm)
+	if err != nil {
+		r.Recorder.Eventf(&cm, corev1.EventTypeWarning, "InvalidKubeConfigMap", "%v", err)
+		log.Info("invalid kubeconfig configmap; ensuring cleanup", "reason", err.Error())
+		return reconcile.Result{}, r.ensureConfigMapRSIPAbsence(ctx, req.NamespacedName)
+	}
+
+	rsip, _, lbls, dv := r.rsipSkeleton(log, rsipInput{
+		Name:        cm.Name,
+		Namespace:   cm.Namespace,
+		Labels:      cm.Labels,
+		Annotations: cm.Annotations,
+		RefLabels: map[string]string{
+			"mirror.fluxcd.io/configMapNS":   cm.Namespace,
+			"mirror.fluxcd.io/configMapName": cm.Name,
+			"mirror.fluxcd.io/provider":      provider,
+		},
+		RefValues: map[string]any{
+			"kubeConfigMapName": cm.Name,
+			"kubeConfigMapNS":   cm.Namespace,
+			"provider":          provider,
+		},
+	})
+
+	desired := newRSIP(rsip.Namespace, rsip.Name, lbls, dv)
+	if err := r.applyRSIP(ctx, log, &cm, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.State.setServer(lbls, strings.TrimSpace(cm.Data["address"])) // cloud providers resolve it at runtime
+	return reconcile.Result{}, r.pruneRSIPs(ctx, log, lbls, rsip)
+}
+
+func (r *KubeConfigMapReconciler) ensureConfigMapRSIPAbsence(ctx context.Context, nn types.NamespacedName) error {
+	return r.deleteRSIPsFor(ctx, "configmap", nn, labels.SelectorFromSet(labels.Set{
+		"mirror.fluxcd.io/configMapNS":   nn.Namespace,
+		"mirror.fluxcd.io/configMapName": nn.Name,
+	}))
+}
+
+// validateKubeConfigMap checks the fields Flux needs for a workload-identity kubeconfig and
+// returns the provider. The cloud providers need "cluster"; "generic" needs "address".
+func validateKubeConfigMap(cm *corev1.ConfigMap) (string, error) {
+	provider := strings.TrimSpace(cm.Data["provider"])
+	if !kubeConfigMapProviders[provider] {
+		return "", fmt.Errorf("unsupported provider %q (want aws, azure, gcp or generic)", provider)
+	}
+	switch provider {
+	case "generic":
+		if strings.TrimSpace(cm.Data["address"]) == "" {
+			return "", fmt.Errorf("provider generic requires address")
+		}
+	default:
+		if strings.TrimSpace(cm.Data["cluster"]) == "" {
+			return "", fmt.Errorf("provider %s requires cluster", provider)
+		}
+	}
+	if addr := strings.TrimSpace(cm.Data["address"]); addr != "" && !strings.HasPrefix(addr, "https://") {
+		return "", fmt.Errorf("address %q must be an https:// URL", addr)
+	}
+	return provider, nil
+}
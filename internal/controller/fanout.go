@@ -0,0 +1,99 @@
+// internal/controller/fanout.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fanoutLabelsAnnotation on a fan-out Namespace sets or overrides labels ("key=value,...")
+// on the RSIPs written there.
+const fanoutLabelsAnnotation = "fcg.io/fanout-labels"
+
+// isFanoutTarget reports whether ns is listed in --fanout-namespaces or matches
+// --fanout-namespace-selector.
+func isFanoutTarget(ns client.Object, opts Options) bool {
+	if toStringSet(opts.FanoutNamespaces).Has(ns.GetName()) {
+		return true
+	}
+	return opts.FanoutNamespaceSelector != nil && opts.FanoutNamespaceSelector.Matches(labels.Set(ns.GetLabels()))
+}
+
+// fanoutTargets returns the existing fan-out Namespaces with their label overrides. Listed
+// namespaces that do not exist (yet) are skipped; creating them re-reconciles every Secret.
+func (r *SecretMirrorReconciler) fanoutTargets(ctx context.Context, log logr.Logger) (map[string]map[string]string, error) {
+	var list corev1.NamespaceList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+	out := map[string]map[string]string{}
+	for i := range list.Items {
+		ns := &list.Items[i]
+		if !isFanoutTarget(ns, r.Opts) || !ns.DeletionTimestamp.IsZero() {
+			continue
+		}
+		overrides, err := parseKeyValues(ns.Annotations[fanoutLabelsAnnotation], true)
+		if err != nil {
+			log.Error(err, "ignoring invalid fan-out label overrides", "namespace", ns.Name)
+			overrides = nil
+		}
+		out[ns.Name] = overrides
+	}
+	return out, nil
+}
+
+// withFanoutOverrides returns lbls with a target's overrides applied; the controller's own
+// mirror.fluxcd.io/* labels cannot be overridden.
+func withFanoutOverrides(lbls, overrides map[string]string) map[string]string {
+	out := maps.Clone(lbls)
+	for k, v := range overrides {
+		if !strings.HasPrefix(k, "mirror.fluxcd.io/") {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// fanoutNamespacePredicate passes Namespace events that add, remove or change a fan-out target.
+func fanoutNamespacePredicate(opts Options) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return isFanoutTarget(e.Object, opts) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			was, is := isFanoutTarget(e.ObjectOld, opts), isFanoutTarget(e.ObjectNew, opts)
+			return was != is || (is &&
+				(e.ObjectOld.GetAnnotations()[fanoutLabelsAnnotation] != e.ObjectNew.GetAnnotations()[fanoutLabelsAnnotation] ||
+					e.ObjectOld.GetDeletionTimestamp().IsZero() != e.ObjectNew.GetDeletionTimestamp().IsZero()))
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isFanoutTarget(e.Object, opts) },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
+// fanoutToSecrets re-reconciles every source Secret when the fan-out targets change.
+func fanoutToSecrets(c client.Reader, matches func(client.Object) bool) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, _ client.Object) []reconcile.Request {
+		var list corev1.SecretList
+		if err := c.List(ctx, &list); err != nil {
+			ctrl.Log.WithName("fanout").Error(err, "list secrets for fan-out change failed")
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			if matches(&list.Items[i]) {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+			}
+		}
+		return reqs
+	}
+}
@@ -0,0 +1,148 @@
+// internal/controller/fleet_info.go
+package controller
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fleetInfoReader reads a well-known ConfigMap (e.g. kube-system/fleet-info) from each
+// target cluster and keeps the allowlisted keys so cluster owners can describe themselves.
+type fleetInfoReader struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Opts     Options
+
+	matches func(client.Object) bool
+	notify  func(types.NamespacedName)
+
+	mu      sync.RWMutex
+	results map[types.NamespacedName]map[string]string
+}
+
+func newFleetInfoReader(c client.Client, rec record.EventRecorder, opts Options,
+	matches func(client.Object) bool, notify func(types.NamespacedName)) *fleetInfoReader {
+	return &fleetInfoReader{
+		Client:   c,
+		Recorder: rec,
+		Opts:     opts,
+		matches:  matches,
+		notify:   notify,
+		results:  map[types.NamespacedName]map[string]string{},
+	}
+}
+
+// Start implements manager.Runnable.
+func (f *fleetInfoReader) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("fleet-info")
+	runEvery(ctx, f.Opts.FleetInfoInterval, func(ctx context.Context) {
+		if err := f.readAll(ctx, log); err != nil {
+			log.Error(err, "fleet info round failed")
+		}
+	})
+	return nil
+}
+
+// apply merges the cluster's self-described data into the RSIP labels and defaultValues.
+// Hub-side values win: keys already set (copied labels, computed values) are left alone.
+func (f *fleetInfoReader) apply(nn types.NamespacedName, lbls map[string]string, dv map[string]any) {
+	f.mu.RLock()
+	data, ok := f.results[nn]
+	f.mu.RUnlock()
+	if !ok {
+		return
+	}
+	for k, v := range data {
+		if _, set := lbls[k]; !set && len(validation.IsQualifiedName(k)) == 0 {
+			if lv := sanitizeLabelValue(v); lv != "" {
+				lbls[k] = lv
+			}
+		}
+		if ck := toCamel(k); dv[ck] == nil {
+			dv[ck] = v
+		}
+	}
+}
+
+func (f *fleetInfoReader) readAll(ctx context.Context, log logr.Logger) error {
+	seen, err := forEachCluster(ctx, f.Client, f.Opts, f.matches, func(ctx context.Context, sec *corev1.Secret) {
+		f.readOne(ctx, log, sec)
+	})
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	for nn := range f.results {
+		if _, ok := seen[nn]; !ok {
+			delete(f.results, nn)
+		}
+	}
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fleetInfoReader) readOne(ctx context.Context, log logr.Logger, sec *corev1.Secret) {
+	nn := client.ObjectKeyFromObject(sec)
+
+	cfg, err := restConfigFromSecret(sec, kubeconfigKey(sec, f.Opts))
+	var data map[string]string
+	if err == nil {
+		data, err = readFleetInfo(ctx, cfg, f.Opts.FleetInfoConfigMap, f.Opts.FleetInfoKeys)
+	}
+	if err != nil {
+		f.Recorder.Eventf(sec, corev1.EventTypeWarning, "FleetInfoReadFailed",
+			"reading %s from cluster failed: %v", f.Opts.FleetInfoConfigMap, err)
+		log.Info("fleet info read failed", "secret", nn.String(), "error", err.Error())
+		return // keep the last known data
+	}
+
+	f.mu.Lock()
+	prev, hadPrev := f.results[nn]
+	f.results[nn] = data
+	f.mu.Unlock()
+	if !hadPrev || !maps.Equal(prev, data) {
+		log.V(1).Info("fleet info changed", "secret", nn.String(), "keys", len(data))
+		f.notify(nn)
+	}
+}
+
+// readFleetInfo fetches the "namespace/name" ConfigMap and returns only the allowed keys.
+// A missing ConfigMap is not an error: the cluster simply describes nothing.
+func readFleetInfo(ctx context.Context, cfg *rest.Config, ref string, allowed []string) (map[string]string, error) {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Timeout = remoteTimeout
+	cs, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	ns, name, _ := strings.Cut(ref, "/")
+	cm, err := cs.CoreV1().ConfigMaps(ns).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]string{}
+	for _, k := range allowed {
+		if v, ok := cm.Data[k]; ok {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
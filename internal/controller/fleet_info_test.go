@@ -0,0 +1,69 @@
+// internal/controller/fleet_info_test.go
+package controller
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestFleetInfoApply(t *testing.T) {
+	nn := types.NamespacedName{Namespace: "p-a", Name: "dev-1"}
+	tests := []struct {
+		name       string
+		data       map[string]string // what the cluster describes; nil: never read
+		lbls       map[string]string
+		dv         map[string]any
+		wantLabels map[string]string
+		wantValues map[string]any
+	}{
+		{
+			name:       "not read yet",
+			lbls:       map[string]string{"env": "prod"},
+			dv:         map[string]any{"name": "dev-1"},
+			wantLabels: map[string]string{"env": "prod"},
+			wantValues: map[string]any{"name": "dev-1"},
+		},
+		{
+			name:       "adds unset keys",
+			data:       map[string]string{"region": "eu-west-1", "team": "payments"},
+			lbls:       map[string]string{},
+			dv:         map[string]any{},
+			wantLabels: map[string]string{"region": "eu-west-1", "team": "payments"},
+			wantValues: map[string]any{"region": "eu-west-1", "team": "payments"},
+		},
+		{
+			name:       "hub labels and values win",
+			data:       map[string]string{"env": "dev", "name": "spoofed", "cost-center": "42"},
+			lbls:       map[string]string{"env": "prod"},
+			dv:         map[string]any{"name": "dev-1", "costCenter": "7"},
+			wantLabels: map[string]string{"env": "prod", "cost-center": "42", "name": "spoofed"},
+			wantValues: map[string]any{"name": "dev-1", "costCenter": "7", "env": "dev"},
+		},
+		{
+			name:       "invalid label key or value only becomes a value",
+			data:       map[string]string{"owner email": "a@b.c", "notes": "free text with spaces"},
+			lbls:       map[string]string{},
+			dv:         map[string]any{},
+			wantLabels: map[string]string{"notes": "free-text-with-spaces"},
+			wantValues: map[string]any{"owneremail": "a@b.c", "notes": "free text with spaces"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFleetInfoReader(nil, nil, Options{}, nil, nil)
+			if tt.data != nil {
+				f.results[nn] = tt.data
+			}
+			f.apply(nn, tt.lbls, tt.dv)
+			if !maps.Equal(tt.lbls, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", tt.lbls, tt.wantLabels)
+			}
+			if !reflect.DeepEqual(tt.dv, tt.wantValues) {
+				t.Errorf("values = %v, want %v", tt.dv, tt.wantValues)
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+// internal/controller/fleet_summary.go
+package controller
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// fleetSummaryLabel marks the shards of the fleet summary ConfigMap.
+	fleetSummaryLabel = "mirror.fluxcd.io/fleetSummary"
+	// fleetShardAnnotation numbers a shard: "<index>/<count>".
+	fleetShardAnnotation = "fcg.io/shard"
+	// fleetSummaryKey is the ConfigMap key holding the clusters of a shard.
+	fleetSummaryKey = "clusters.yaml"
+	// fleetShardBytes keeps each shard well below the 1 MiB object size limit.
+	fleetShardBytes = 768 << 10
+	// fleetSummaryInterval bounds how often the summary is rewritten after changes.
+	fleetSummaryInterval = 10 * time.Second
+)
+
+// fleetEntry is one generated RSIP in the fleet summary.
+type fleetEntry struct {
+	Cluster string            `json:"cluster"`
+	Project string            `json:"project,omitempty"`
+	RSIP    string            `json:"rsip"`             // namespace/name
+	Source  string            `json:"source,omitempty"` // Kind namespace/name
+	Labels  map[string]string `json:"labels,omitempty"` // copied labels
+	Updated string            `json:"updated,omitempty"`
+}
+
+// rsipSource describes the source object of an RSIP from its reference labels.
+func rsipSource(lbls map[string]string) string {
+	switch {
+	case lbls[localClusterLabel] == "true":
+		return "LocalCluster"
+	case lbls[managedClusterLabel] != "":
+		return "ManagedCluster " + lbls[managedClusterLabel]
+	case lbls[inventoryEntryLabel] != "":
+		return "InventoryEntry " + lbls[inventoryEntryLabel]
+	case lbls[cmdbEntryLabel] != "":
+		return "CMDBEntry " + lbls[cmdbEntryLabel]
+	case lbls["mirror.fluxcd.io/clusterProfileName"] != "":
+		return "ClusterProfile " + lbls["mirror.fluxcd.io/clusterProfileNS"] + "/" + lbls["mirror.fluxcd.io/clusterProfileName"]
+	case lbls["mirror.fluxcd.io/configMapName"] != "":
+		return "ConfigMap " + lbls["mirror.fluxcd.io/configMapNS"] + "/" + lbls["mirror.fluxcd.io/configMapName"]
+	case lbls["mirror.fluxcd.io/secretName"] != "":
+		return "Secret " + lbls["mirror.fluxcd.io/secretNS"] + "/" + lbls["mirror.fluxcd.io/secretName"]
+	}
+	return ""
+}
+
+// lastUpdate is when the generator last wrote obj, from its own managedFields entry (its
+// creation if there is none). Writes by other managers, such as the rollout annotations,
+// do not count.
+func lastUpdate(obj client.Object) time.Time {
+	t := obj.GetCreationTimestamp().Time
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager == rsipFieldManager && mf.Time != nil && mf.Time.After(t) {
+			t = mf.Time.Time
+		}
+	}
+	return t
+}
+
+// fleetSummary maintains the --fleet-summary-configmap: every generated RSIP with its cluster,
+// source, project, copied labels and last update, ordered by cluster and RSIP, sharded into
+// <name>, <name>-1, ... when it outgrows a single ConfigMap.
+type fleetSummary struct {
+	Cache     cache.Cache
+	Client    client.Client
+	APIReader client.Reader
+	Opts      Options
+
+	dirty atomic.Bool
+}
+
+// Start implements manager.Runnable.
+func (f *fleetSummary) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("fleet-summary").WithValues("configMap", f.Opts.FleetSummaryNamespace+"/"+f.Opts.FleetSummaryConfigMap)
+	rsip := &unstructured.Unstructured{}
+	rsip.SetGroupVersionKind(rsipGVK)
+	inf, err := f.Cache.GetInformer(ctx, rsip)
+	if err != nil {
+		return fmt.Errorf("get RSIP informer: %w", err)
+	}
+	mark := func(any) { f.dirty.Store(true) }
+	if _, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    mark,
+		UpdateFunc: func(_, obj any) { mark(obj) },
+		DeleteFunc: mark,
+	}); err != nil {
+		return fmt.Errorf("add RSIP event handler: %w", err)
+	}
+
+	f.dirty.Store(true)
+	runEvery(ctx, fleetSummaryInterval, func(ctx context.Context) {
+		if !f.dirty.Swap(false) {
+			return
+		}
+		if err := f.write(ctx, log); err != nil {
+			f.dirty.Store(true) // retry on the next tick
+			log.Error(err, "fleet summary update failed")
+		}
+	})
+	return nil
+}
+
+// entries lists the managed RSIPs from the cache, ordered by cluster and RSIP.
+func (f *fleetSummary) entries(ctx context.Context) ([]fleetEntry, error) {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := f.Cache.List(ctx, &list,
+		managedRSIPs(labels.Everything()),
+	); err != nil {
+		return nil, fmt.Errorf("list RSIPs: %w", err)
+	}
+	out := make([]fleetEntry, 0, len(list.Items))
+	for i := range list.Items {
+		rsip := &list.Items[i]
+		lbls := rsip.GetLabels()
+		e := fleetEntry{
+			Cluster: lbls["mirror.fluxcd.io/clusterName"],
+			Project: lbls["mirror.fluxcd.io/project"],
+			RSIP:    rsip.GetNamespace() + "/" + rsip.GetName(),
+			Source:  rsipSource(lbls),
+		}
+		for k, v := range lbls {
+			if !strings.HasPrefix(k, "mirror.fluxcd.io/") {
+				if e.Labels == nil {
+					e.Labels = map[string]string{}
+				}
+				e.Labels[k] = v
+			}
+		}
+		if t := lastUpdate(rsip); !t.IsZero() {
+			e.Updated = t.UTC().Format(time.RFC3339)
+		}
+		out = append(out, e)
+	}
+	slices.SortFunc(out, func(a, b fleetEntry) int {
+		return cmp.Or(cmp.Compare(a.Cluster, b.Cluster), cmp.Compare(a.RSIP, b.RSIP))
+	})
+	return out, nil
+}
+
+// shardFleet renders the entries as YAML lists, split so each shard stays below fleetShardBytes.
+func shardFleet(entries []fleetEntry) ([]string, error) {
+	shards := []string{""}
+	for _, e := range entries {
+		raw, err := yaml.Marshal([]fleetEntry{e})
+		if err != nil {
+			return nil, err
+		}
+		last := len(shards) - 1
+		if shards[last] != "" && len(shards[last])+len(raw) > fleetShardBytes {
+			shards = append(shards, "")
+			last++
+		}
+		shards[last] += string(raw)
+	}
+	if shards[0] == "" {
+		shards[0] = "[]\n"
+	}
+	return shards, nil
+}
+
+// fleetShardName is the name of shard i of the summary ConfigMap base.
+func fleetShardName(base string, i int) string {
+	if i == 0 {
+		return base
+	}
+	return base + "-" + strconv.Itoa(i)
+}
+
+func (f *fleetSummary) write(ctx context.Context, log logr.Logger) error {
+	entries, err := f.entries(ctx)
+	if err != nil {
+		return err
+	}
+	shards, err := shardFleet(entries)
+	if err != nil {
+		return err
+	}
+
+	base := f.Opts.FleetSummaryConfigMap
+	keep := map[string]bool{}
+	var errs []error
+	for i, data := range shards {
+		desired := &corev1.ConfigMap{}
+		desired.SetNamespace(f.Opts.FleetSummaryNamespace)
+		desired.SetName(fleetShardName(base, i))
+		desired.SetLabels(map[string]string{fleetSummaryLabel: base})
+		desired.SetAnnotations(map[string]string{fleetShardAnnotation: fmt.Sprintf("%d/%d", i, len(shards))})
+		desired.Data = map[string]string{fleetSummaryKey: data}
+		keep[desired.Name] = true
+		errs = append(errs, f.apply(ctx, desired))
+	}
+
+	// drop shards no longer needed
+	var list corev1.ConfigMapList
+	if err := f.APIReader.List(ctx, &list,
+		client.InNamespace(f.Opts.FleetSummaryNamespace),
+		client.MatchingLabels{fleetSummaryLabel: base},
+	); err != nil {
+		return errors.Join(append(errs, fmt.Errorf("list fleet summary shards: %w", err))...)
+	}
+	for i := range list.Items {
+		if keep[list.Items[i].Name] {
+			continue
+		}
+		if err := f.Client.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	log.V(1).Info("fleet summary updated", "clusters", len(entries), "shards", len(shards))
+	return nil
+}
+
+// apply creates desired or updates it when its data or shard annotation changed.
+func (f *fleetSummary) apply(ctx context.Context, desired *corev1.ConfigMap) error {
+	var existing corev1.ConfigMap
+	err := f.APIReader.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return err
+	case err != nil:
+		return f.Client.Create(ctx, desired)
+	case existing.Data[fleetSummaryKey] == desired.Data[fleetSummaryKey] &&
+		existing.Annotations[fleetShardAnnotation] == desired.Annotations[fleetShardAnnotation] &&
+		existing.Labels[fleetSummaryLabel] == desired.Labels[fleetSummaryLabel]:
+		return nil
+	}
+	existing.Data = desired.Data
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	existing.Labels[fleetSummaryLabel] = desired.Labels[fleetSummaryLabel]
+	if existing.Annotations == nil {
+		existing.Annotations = map[string]string{}
+	}
+	existing.Annotations[fleetShardAnnotation] = desired.Annotations[fleetShardAnnotation]
+	return f.Client.Update(ctx, &existing)
+}
@@ -0,0 +1,206 @@
+// internal/controller/fleet_summary_test.go
+package controller
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// sizedEntry returns a fleet entry for cluster that renders to exactly size bytes of YAML.
+func sizedEntry(t *testing.T, cluster string, size int) fleetEntry {
+	t.Helper()
+	e := fleetEntry{Cluster: cluster, RSIP: "flux-system/" + cluster, Labels: map[string]string{"pad": "x"}}
+	raw, err := yaml.Marshal([]fleetEntry{e})
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Labels["pad"] = strings.Repeat("x", size-len(raw)+1)
+	if raw, _ = yaml.Marshal([]fleetEntry{e}); len(raw) != size {
+		t.Fatalf("entry %s renders to %d bytes, want %d", cluster, len(raw), size)
+	}
+	return e
+}
+
+func TestShardFleet(t *testing.T) {
+	const small = 200
+	tests := []struct {
+		name    string
+		entries func(t *testing.T) []fleetEntry
+		want    int // shards
+	}{
+		{name: "no entries", entries: func(*testing.T) []fleetEntry { return nil }, want: 1},
+		{
+			name: "exactly at the limit",
+			entries: func(t *testing.T) []fleetEntry {
+				return []fleetEntry{sizedEntry(t, "dev-1", fleetShardBytes-small), sizedEntry(t, "dev-2", small)}
+			},
+			want: 1,
+		},
+		{
+			name: "one byte over the limit",
+			entries: func(t *testing.T) []fleetEntry {
+				return []fleetEntry{sizedEntry(t, "dev-1", fleetShardBytes-small), sizedEntry(t, "dev-2", small+1)}
+			},
+			want: 2,
+		},
+		{
+			name: "oversized entry first keeps its own shard",
+			entries: func(t *testing.T) []fleetEntry {
+				return []fleetEntry{sizedEntry(t, "dev-1", fleetShardBytes+1), sizedEntry(t, "dev-2", small)}
+			},
+			want: 2,
+		},
+		{
+			name: "oversized entry after others starts a new shard",
+			entries: func(t *testing.T) []fleetEntry {
+				return []fleetEntry{sizedEntry(t, "dev-1", small), sizedEntry(t, "dev-2", fleetShardBytes+1)}
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.entries(t)
+			shards, err := shardFleet(entries)
+			if err != nil {
+				t.Fatalf("shardFleet: %v", err)
+			}
+			if len(shards) != tt.want {
+				t.Fatalf("got %d shards, want %d", len(shards), tt.want)
+			}
+			var all []fleetEntry
+			for i, s := range shards {
+				if s == "" {
+					t.Errorf("shard %d is empty", i)
+				}
+				var part []fleetEntry
+				if err := yaml.Unmarshal([]byte(s), &part); err != nil {
+					t.Fatalf("shard %d: %v", i, err)
+				}
+				all = append(all, part...)
+			}
+			if len(entries) == 0 {
+				if shards[0] != "[]\n" {
+					t.Errorf("empty summary = %q, want %q", shards[0], "[]\n")
+				}
+				return
+			}
+			if !reflect.DeepEqual(all, entries) {
+				t.Errorf("shards do not hold the entries in order")
+			}
+		})
+	}
+}
+
+func TestRsipSource(t *testing.T) {
+	tests := []struct {
+		name string
+		lbls map[string]string
+		want string
+	}{
+		{name: "no reference labels", lbls: map[string]string{"env": "dev"}},
+		{
+			name: "Secret",
+			lbls: map[string]string{"mirror.fluxcd.io/secretNS": "p-a", "mirror.fluxcd.io/secretName": "dev-1"},
+			want: "Secret p-a/dev-1",
+		},
+		{
+			name: "ConfigMap",
+			lbls: map[string]string{"mirror.fluxcd.io/configMapNS": "p-a", "mirror.fluxcd.io/configMapName": "dev-1"},
+			want: "ConfigMap p-a/dev-1",
+		},
+		{
+			name: "ClusterProfile",
+			lbls: map[string]string{"mirror.fluxcd.io/clusterProfileNS": "fleet", "mirror.fluxcd.io/clusterProfileName": "dev-1"},
+			want: "ClusterProfile fleet/dev-1",
+		},
+		{name: "inventory entry", lbls: map[string]string{inventoryEntryLabel: "dev-1"}, want: "InventoryEntry dev-1"},
+		{name: "CMDB entry", lbls: map[string]string{cmdbEntryLabel: "ci-42"}, want: "CMDBEntry ci-42"},
+		{
+			name: "ManagedCluster wins over its kubeconfig Secret",
+			lbls: map[string]string{
+				managedClusterLabel:           "dev-1",
+				"mirror.fluxcd.io/secretNS":   "dev-1",
+				"mirror.fluxcd.io/secretName": "dev-1-kubeconfig",
+			},
+			want: "ManagedCluster dev-1",
+		},
+		{
+			name: "ClusterProfile wins over its ConfigMap",
+			lbls: map[string]string{
+				"mirror.fluxcd.io/clusterProfileNS":   "fleet",
+				"mirror.fluxcd.io/clusterProfileName": "dev-1",
+				"mirror.fluxcd.io/configMapNS":        "fleet",
+				"mirror.fluxcd.io/configMapName":      "dev-1",
+			},
+			want: "ClusterProfile fleet/dev-1",
+		},
+		{
+			name: "local cluster wins over everything",
+			lbls: map[string]string{localClusterLabel: "true", managedClusterLabel: "local-cluster"},
+			want: "LocalCluster",
+		},
+		{name: "local label not true", lbls: map[string]string{localClusterLabel: "false"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rsipSource(tt.lbls); got != tt.want {
+				t.Errorf("rsipSource = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastUpdate(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	entry := func(manager string, at time.Time) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, Time: &metav1.Time{Time: at}}
+	}
+	tests := []struct {
+		name   string
+		fields []metav1.ManagedFieldsEntry
+		want   time.Time
+	}{
+		{name: "no managed fields", want: created},
+		{
+			name:   "own write",
+			fields: []metav1.ManagedFieldsEntry{entry(rsipFieldManager, created.Add(time.Hour))},
+			want:   created.Add(time.Hour),
+		},
+		{
+			name: "rollout annotations do not count",
+			fields: []metav1.ManagedFieldsEntry{
+				entry(rsipFieldManager, created.Add(time.Hour)),
+				entry(rolloutFieldManager, created.Add(2*time.Hour)),
+			},
+			want: created.Add(time.Hour),
+		},
+		{
+			name:   "only other managers",
+			fields: []metav1.ManagedFieldsEntry{entry("kubectl-edit", created.Add(time.Hour))},
+			want:   created,
+		},
+		{
+			name:   "own entry without time",
+			fields: []metav1.ManagedFieldsEntry{{Manager: rsipFieldManager, Operation: metav1.ManagedFieldsOperationUpdate}},
+			want:   created,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rsip unstructured.Unstructured
+			rsip.SetGroupVersionKind(rsipGVK)
+			rsip.SetCreationTimestamp(metav1.NewTime(created))
+			rsip.SetManagedFields(tt.fields)
+			if got := lastUpdate(&rsip); !got.Equal(tt.want) {
+				t.Errorf("lastUpdate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -13,20 +15,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// sweepOrphanRSIPs scans RSIPs in rsipNS and deletes any whose referenced Secret no longer exists.
+// sweepOrphanRSIPs scans the managed RSIPs in all namespaces and deletes any whose referenced Secret (or ConfigMap, ManagedCluster, ClusterProfile) no longer exists.
 func sweepOrphanRSIPs(
 	ctx context.Context,
 	log logr.Logger,
 	reader client.Reader, // uncached read
 	writer client.Client, // for deletes
-	rsipNS string,
 ) error {
 	var rsips unstructured.UnstructuredList
 	rsips.SetGroupVersionKind(schema.GroupVersionKind{
 		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
 	})
 
-	if err := reader.List(ctx, &rsips, client.InNamespace(rsipNS)); err != nil {
+	if err := reader.List(ctx, &rsips, managedRSIPs(labels.Everything())); err != nil {
 		return fmt.Errorf("list RSIPs: %w", err)
 	}
 
@@ -34,36 +35,194 @@ func sweepOrphanRSIPs(
 	for i := range rsips.Items {
 		rsip := &rsips.Items[i]
 		lbl := rsip.GetLabels()
-		secNS, okNS := lbl["mirror.fluxcd.io/secretNS"]
-		secName, okName := lbl["mirror.fluxcd.io/secretName"]
-		if !okNS || !okName || secNS == "" || secName == "" {
+
+		// Which source object does the RSIP reference?
+		var src client.Object
+		srcNS, srcName := lbl["mirror.fluxcd.io/secretNS"], lbl["mirror.fluxcd.io/secretName"]
+		switch {
+		case lbl[managedClusterLabel] != "":
+			// OCM RSIPs follow their (cluster-scoped) ManagedCluster; the Secret is checked on reconcile
+			srcNS, srcName = "", lbl[managedClusterLabel]
+			mc := &unstructured.Unstructured{}
+			mc.SetGroupVersionKind(managedClusterGVK)
+			src = mc
+		case srcNS != "" && srcName != "":
+			src = &corev1.Secret{}
+		case lbl["mirror.fluxcd.io/configMapNS"] != "" && lbl["mirror.fluxcd.io/configMapName"] != "":
+			srcNS, srcName = lbl["mirror.fluxcd.io/configMapNS"], lbl["mirror.fluxcd.io/configMapName"]
+			src = &corev1.ConfigMap{}
+		case lbl["mirror.fluxcd.io/clusterProfileNS"] != "" && lbl["mirror.fluxcd.io/clusterProfileName"] != "":
+			srcNS, srcName = lbl["mirror.fluxcd.io/clusterProfileNS"], lbl["mirror.fluxcd.io/clusterProfileName"]
+			cp := &unstructured.Unstructured{}
+			cp.SetGroupVersionKind(clusterProfileGVK)
+			src = cp
+		default:
 			continue // not managed by us
 		}
+		srcRef := fmt.Sprintf("%s/%s", srcNS, srcName)
+		if srcNS == "" {
+			srcRef = srcName
+		}
 
-		// Does the Secret still exist?
-		var sec corev1.Secret
-		err := reader.Get(ctx, types.NamespacedName{Namespace: secNS, Name: secName}, &sec)
+		// Does the source still exist?
+		err := reader.Get(ctx, types.NamespacedName{Namespace: srcNS, Name: srcName}, src)
 		if client.IgnoreNotFound(err) != nil {
-			log.Error(err, "secret existence check failed",
-				"rsip", rsip.GetName(), "secret", fmt.Sprintf("%s/%s", secNS, secName))
+			log.Error(err, "source existence check failed", "rsip", rsip.GetName(), "source", srcRef)
 			continue
 		}
 		if err == nil {
-			continue // Secret exists -> keep RSIP
+			continue // source exists -> keep RSIP
 		}
 
-		// Secret not found -> delete the RSIP
+		// source not found -> delete the RSIP
 		if err := writer.Delete(ctx, rsip); client.IgnoreNotFound(err) != nil {
 			log.Error(err, "failed deleting orphan RSIP", "name", rsip.GetName())
 		} else {
 			deleted++
-			log.Info("deleted orphan RSIP", "name", rsip.GetName(),
-				"secret", fmt.Sprintf("%s/%s", secNS, secName))
+			log.Info("deleted orphan RSIP", "name", rsip.GetName(), "source", srcRef)
 		}
 	}
 
 	if deleted > 0 {
-		log.Info("orphan RSIP sweep complete", "namespace", rsipNS, "deleted", deleted)
+		log.Info("orphan RSIP sweep complete", "deleted", deleted)
 	}
 	return nil
 }
+
+// orphanSource locates the source Secret of a generated object: the reader to look it up with
+// (nil when the source is known to be gone) and its key. ok is false for objects without a
+// source reference, which are left alone.
+type orphanSource func(obj client.Object) (src client.Reader, key types.NamespacedName, ok bool)
+
+// labeledSource is the orphanSource of objects naming their source Secret in the nsKey and
+// nameKey labels, looked up with reader.
+func labeledSource(reader client.Reader, nsKey, nameKey string) orphanSource {
+	return func(obj client.Object) (client.Reader, types.NamespacedName, bool) {
+		ns, name := obj.GetLabels()[nsKey], obj.GetLabels()[nameKey]
+		return reader, types.NamespacedName{Namespace: ns, Name: name}, ns != "" && name != ""
+	}
+}
+
+// sweepOrphans lists the generated objects (what names them in logs) into list and deletes
+// those whose source Secret no longer exists. Failed existence checks keep the object.
+func sweepOrphans(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+	what string,
+	list client.ObjectList,
+	source orphanSource,
+	opts ...client.ListOption,
+) error {
+	if err := reader.List(ctx, list, opts...); err != nil {
+		return fmt.Errorf("list %ss: %w", what, err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			continue
+		}
+		src, key, ok := source(obj)
+		if !ok {
+			continue
+		}
+		if src != nil {
+			err := src.Get(ctx, key, &corev1.Secret{})
+			if client.IgnoreNotFound(err) != nil {
+				log.Error(err, "source existence check failed", what, client.ObjectKeyFromObject(obj).String())
+				continue
+			}
+			if err == nil {
+				continue
+			}
+		}
+		if err := writer.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "failed deleting orphan "+what, what, client.ObjectKeyFromObject(obj).String())
+		} else {
+			log.Info("deleted orphan "+what, what, client.ObjectKeyFromObject(obj).String(), "source", key.String())
+		}
+	}
+	return nil
+}
+
+// sweepOrphanArgoSecrets deletes kubeconfig Secrets synthesized from Argo CD cluster Secrets that no longer exist.
+func sweepOrphanArgoSecrets(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+	targetNS string,
+) error {
+	return sweepOrphans(ctx, log, reader, writer, "generated secret", &corev1.SecretList{},
+		labeledSource(reader, "mirror.fluxcd.io/argocdSecretNS", "mirror.fluxcd.io/argocdSecretName"),
+		client.InNamespace(targetNS), client.MatchingLabels{generatedByLabel: generatedByArgo})
+}
+
+// sweepOrphanArgoOutputSecrets deletes Argo CD cluster Secrets written for Flux kubeconfig Secrets that no longer exist.
+func sweepOrphanArgoOutputSecrets(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+	outputNS string,
+) error {
+	return sweepOrphans(ctx, log, reader, writer, "Argo CD cluster secret", &corev1.SecretList{},
+		labeledSource(reader, "mirror.fluxcd.io/secretNS", "mirror.fluxcd.io/secretName"),
+		client.InNamespace(outputNS), client.MatchingLabels{argoOutputLabel: "true"})
+}
+
+// sweepOrphanClusterProfiles deletes ClusterProfiles written for Flux kubeconfig Secrets that no longer exist.
+func sweepOrphanClusterProfiles(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+	outputNS string,
+) error {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: clusterProfileGVK.Group, Version: clusterProfileGVK.Version, Kind: clusterProfileGVK.Kind + "List",
+	})
+	return sweepOrphans(ctx, log, reader, writer, "ClusterProfile", &list,
+		labeledSource(reader, "mirror.fluxcd.io/secretNS", "mirror.fluxcd.io/secretName"),
+		client.InNamespace(outputNS), client.MatchingLabels{clusterProfileOutputLabel: "true"})
+}
+
+// sweepOrphanRemoteCopies deletes hub copies of remote kubeconfig Secrets whose source Secret, or whose
+// remote management cluster, is gone. remotes maps source cluster names to uncached remote readers.
+func sweepOrphanRemoteCopies(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+	targetNS string,
+	remotes map[string]client.Reader,
+) error {
+	source := func(obj client.Object) (client.Reader, types.NamespacedName, bool) {
+		// a remote removed from --remote-kubeconfigs leaves a nil reader: its copies go;
+		// an unreachable remote fails the check instead and must not wipe its clusters
+		lbls := obj.GetLabels()
+		ns, name := lbls["mirror.fluxcd.io/remoteSecretNS"], lbls["mirror.fluxcd.io/remoteSecretName"]
+		return remotes[lbls[sourceClusterLabel]], types.NamespacedName{Namespace: ns, Name: name}, ns != "" && name != ""
+	}
+	return sweepOrphans(ctx, log, reader, writer, "secret copy", &corev1.SecretList{}, source,
+		client.InNamespace(targetNS), client.MatchingLabels{generatedByLabel: generatedByRemote})
+}
+
+// sweepOrphanReplicas deletes kubeconfig replicas (in any namespace) whose source Secret no longer exists.
+func sweepOrphanReplicas(
+	ctx context.Context,
+	log logr.Logger,
+	reader client.Reader,
+	writer client.Client,
+) error {
+	return sweepOrphans(ctx, log, reader, writer, "kubeconfig replica", &corev1.SecretList{},
+		labeledSource(reader, "mirror.fluxcd.io/secretNS", "mirror.fluxcd.io/secretName"),
+		client.MatchingLabels{replicaLabel: "true"})
+}
@@ -0,0 +1,96 @@
+// internal/controller/gc_test.go
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testSecret(ns, name string, lbls map[string]string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name, Labels: lbls}}
+}
+
+// exists reports whether the Secret ns/name is still there.
+func exists(t *testing.T, c client.Reader, ns, name string) bool {
+	t.Helper()
+	err := c.Get(context.Background(), client.ObjectKey{Namespace: ns, Name: name}, &corev1.Secret{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		t.Fatalf("get %s/%s: %v", ns, name, err)
+	}
+	return err == nil
+}
+
+func TestSweepOrphanReplicas(t *testing.T) {
+	replica := func(name, srcNS, srcName string) *corev1.Secret {
+		return testSecret("flux-system", name, map[string]string{
+			replicaLabel:                  "true",
+			"mirror.fluxcd.io/secretNS":   srcNS,
+			"mirror.fluxcd.io/secretName": srcName,
+		})
+	}
+	c := fake.NewClientBuilder().WithObjects(
+		testSecret("p-a", "dev-1", nil),
+		replica("kubeconfig.p-a.dev-1", "p-a", "dev-1"),
+		replica("kubeconfig.p-a.dev-2", "p-a", "dev-2"),
+		testSecret("flux-system", "unrelated", map[string]string{replicaLabel: "true"}),
+	).Build()
+
+	if err := sweepOrphanReplicas(context.Background(), logr.Discard(), c, c); err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{name: "kubeconfig.p-a.dev-1", want: true},  // source exists
+		{name: "kubeconfig.p-a.dev-2", want: false}, // source gone
+		{name: "unrelated", want: true},             // no source reference
+	} {
+		if got := exists(t, c, "flux-system", tt.name); got != tt.want {
+			t.Errorf("%s exists = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSweepOrphanRemoteCopies(t *testing.T) {
+	copyOf := func(cluster, srcName string) *corev1.Secret {
+		return testSecret("fleet", cluster+".p-a."+srcName, map[string]string{
+			generatedByLabel:                    generatedByRemote,
+			sourceClusterLabel:                  cluster,
+			"mirror.fluxcd.io/remoteSecretNS":   "p-a",
+			"mirror.fluxcd.io/remoteSecretName": srcName,
+		})
+	}
+	hub := fake.NewClientBuilder().WithObjects(
+		copyOf("eu", "dev-1"),
+		copyOf("eu", "dev-2"),
+		copyOf("us", "dev-1"),
+	).Build()
+	eu := fake.NewClientBuilder().WithObjects(testSecret("p-a", "dev-1", nil)).Build()
+
+	// "us" is no longer configured, so its copies go regardless of the source
+	err := sweepOrphanRemoteCopies(context.Background(), logr.Discard(), hub, hub, "fleet",
+		map[string]client.Reader{"eu": eu})
+	if err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	for _, tt := range []struct {
+		name string
+		want bool
+	}{
+		{name: "eu.p-a.dev-1", want: true},
+		{name: "eu.p-a.dev-2", want: false},
+		{name: "us.p-a.dev-1", want: false},
+	} {
+		if got := exists(t, hub, "fleet", tt.name); got != tt.want {
+			t.Errorf("%s exists = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -39,6 +39,33 @@ func sanitizeDNS1123(in string) string {
 	return out
 }
 
+// sanitizeLabelValue makes s usable as a label value (alnum, '-', '_', '.', max 63, alnum at both ends).
+func sanitizeLabelValue(s string) string {
+	sb := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+			sb = append(sb, c)
+		default:
+			sb = append(sb, '-')
+		}
+	}
+	if len(sb) > 63 {
+		sb = sb[:63]
+	}
+	isAlnum := func(c byte) bool {
+		return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+	for len(sb) > 0 && !isAlnum(sb[0]) {
+		sb = sb[1:]
+	}
+	for len(sb) > 0 && !isAlnum(sb[len(sb)-1]) {
+		sb = sb[:len(sb)-1]
+	}
+	return string(sb)
+}
+
 // Prefix matcher for label-copy
 func hasAnyPrefix(prefixes []string, key string) bool {
 	for _, p := range prefixes {
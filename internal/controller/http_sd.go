@@ -0,0 +1,94 @@
+// internal/controller/http_sd.go
+package controller
+
+import (
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// httpSDMetaPrefix prefixes the labels of the Prometheus http_sd targets. Like every __meta_*
+// label they are dropped after relabeling unless copied.
+const httpSDMetaPrefix = "__meta_fcg_"
+
+// invalidPromLabelChars are the characters not allowed in a Prometheus label name.
+var invalidPromLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// httpSDTargetGroup is one element of a Prometheus http_sd response.
+type httpSDTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// recordServer remembers the API server of the kubeconfig in sec.Data[key] for the source
+// identified by lbls. The kubeconfig is only parsed when the state is kept.
+func (r *SecretMirrorReconciler) recordServer(log logr.Logger, lbls map[string]string, sec *corev1.Secret, key string) {
+	if r.State == nil {
+		return
+	}
+	cfg, err := restConfigFromSecret(sec, key)
+	if err != nil {
+		log.V(1).Info("no API server for service discovery", "reason", err.Error())
+		return
+	}
+	r.State.setServer(lbls, cfg.Host)
+}
+
+// httpSDGroups turns the clusters with an API server into http_sd target groups, one per
+// source. The target is the server's host:port; the labels come from the source's first RSIP.
+func httpSDGroups(clusters []clusterView) []httpSDTargetGroup {
+	out := []httpSDTargetGroup{}
+	for _, c := range clusters {
+		if c.Server == "" || len(c.RSIPs) == 0 {
+			continue // skipped sources have no cluster to monitor
+		}
+		target, ok := serverTarget(c.Server)
+		if !ok {
+			continue
+		}
+		rsip := c.RSIPs[0]
+		lbls := map[string]string{
+			httpSDMetaPrefix + "cluster":        c.Name,
+			httpSDMetaPrefix + "source":         c.Source,
+			httpSDMetaPrefix + "server":         c.Server,
+			httpSDMetaPrefix + "rsip_namespace": rsip.Namespace,
+			httpSDMetaPrefix + "rsip_name":      rsip.Name,
+		}
+		if c.Project != "" {
+			lbls[httpSDMetaPrefix+"project"] = c.Project
+		}
+		for k, v := range rsip.Labels {
+			if strings.HasPrefix(k, "mirror.fluxcd.io/") {
+				continue // bookkeeping; cluster, project and source are above
+			}
+			lbls[httpSDMetaPrefix+"label_"+invalidPromLabelChars.ReplaceAllString(k, "_")] = v
+		}
+		out = append(out, httpSDTargetGroup{Targets: []string{target}, Labels: lbls})
+	}
+	// clusters are ordered by name and source; keep targets in that order but drop duplicates
+	// (the same server behind several sources would be scraped twice)
+	return slices.CompactFunc(out, func(a, b httpSDTargetGroup) bool {
+		return a.Targets[0] == b.Targets[0] && a.Labels[httpSDMetaPrefix+"cluster"] == b.Labels[httpSDMetaPrefix+"cluster"]
+	})
+}
+
+// serverTarget returns host:port of an API server URL, defaulting the port by scheme.
+func serverTarget(server string) (string, bool) {
+	u, err := url.Parse(server)
+	if err != nil || u.Hostname() == "" {
+		return "", false
+	}
+	if u.Port() != "" {
+		return u.Host, true
+	}
+	port := "443"
+	if u.Scheme == "http" {
+		port = "80"
+	}
+	return net.JoinHostPort(u.Hostname(), port), true
+}
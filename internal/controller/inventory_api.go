@@ -0,0 +1,131 @@
+// internal/controller/inventory_api.go
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// inventoryAPIShutdownTimeout bounds how long in-flight requests may finish on shutdown.
+const inventoryAPIShutdownTimeout = 5 * time.Second
+
+// inventoryAPI serves the reconcilers' in-memory fleet state read-only over HTTP:
+//
+//	GET /v1/clusters[?labelSelector=...]
+//	GET /v1/clusters/{name}[?labelSelector=...]
+//	GET /v1/http-sd[?labelSelector=...]   (Prometheus http_sd targets, see httpSDGroups)
+//
+// Responses carry an ETag; a matching If-None-Match gets 304. Only the state the controller
+// computed is exposed (defaultValues, RSIP location, source reference, skip reason), never
+// kubeconfig data. Like the reconcilers that fill the state, it runs on the leader only.
+type inventoryAPI struct {
+	State       *clusterState
+	BindAddress string
+}
+
+// Start implements manager.Runnable.
+func (a *inventoryAPI) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("inventory-api").WithValues("address", a.BindAddress)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/clusters", func(w http.ResponseWriter, req *http.Request) {
+		a.serve(w, req, "")
+	})
+	mux.HandleFunc("GET /v1/clusters/{name}", func(w http.ResponseWriter, req *http.Request) {
+		a.serve(w, req, req.PathValue("name"))
+	})
+	mux.HandleFunc("GET /v1/http-sd", a.serveHTTPSD)
+	srv := &http.Server{
+		Addr:              a.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: remoteTimeout,
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+	log.Info("serving inventory API")
+
+	select {
+	case err := <-errc:
+		return fmt.Errorf("inventory API: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), inventoryAPIShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("inventory API shutdown: %w", err)
+	}
+	return nil
+}
+
+// serve writes the clusters matching the request; name selects a single cluster.
+func (a *inventoryAPI) serve(w http.ResponseWriter, req *http.Request, name string) {
+	sel, ok := requestSelector(w, req)
+	if !ok {
+		return
+	}
+
+	var body any
+	clusters := a.State.list(name, sel)
+	if name == "" {
+		body = map[string]any{"clusters": clusters}
+	} else {
+		if len(clusters) == 0 {
+			http.Error(w, "cluster not found", http.StatusNotFound)
+			return
+		}
+		// a cluster name may come from several sources; all of them are returned
+		body = map[string]any{"name": name, "sources": clusters}
+	}
+	writeJSON(w, req, body)
+}
+
+// serveHTTPSD writes the matching clusters with an API server as Prometheus http_sd targets.
+func (a *inventoryAPI) serveHTTPSD(w http.ResponseWriter, req *http.Request) {
+	sel, ok := requestSelector(w, req)
+	if !ok {
+		return
+	}
+	writeJSON(w, req, httpSDGroups(a.State.list("", sel)))
+}
+
+// requestSelector parses the labelSelector query parameter, answering 400 when it is invalid.
+func requestSelector(w http.ResponseWriter, req *http.Request) (labels.Selector, bool) {
+	s := req.URL.Query().Get("labelSelector")
+	if s == "" {
+		return labels.Everything(), true
+	}
+	sel, err := labels.Parse(s)
+	if err != nil {
+		http.Error(w, "invalid labelSelector: "+err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+	return sel, true
+}
+
+// writeJSON writes body as JSON with a content-hash ETag, or 304 when the client has it.
+func writeJSON(w http.ResponseWriter, req *http.Request, body any) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha256.Sum256(raw)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if req.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(raw)
+}
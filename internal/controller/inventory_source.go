@@ -0,0 +1,347 @@
+// internal/controller/inventory_source.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// inventoryEntryLabel links an RSIP to the --inventory-file entry it was generated for.
+	inventoryEntryLabel = "mirror.fluxcd.io/inventoryEntry"
+	// inventoryDebounce coalesces the burst of events a file write (or ConfigMap symlink swap) produces.
+	inventoryDebounce = 500 * time.Millisecond
+	// inventorySecretRetry is how often an entry whose Secret is missing is retried.
+	inventorySecretRetry = time.Minute
+)
+
+// inventoryFile is the --inventory-file format.
+type inventoryFile struct {
+	Clusters []inventoryEntry `json:"clusters"`
+}
+
+// inventoryEntry declares one cluster in place of a labeled Secret.
+type inventoryEntry struct {
+	Name        string            `json:"name"`
+	SecretRef   inventorySecret   `json:"secretRef"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type inventorySecret struct {
+	Namespace string `json:"namespace,omitempty"` // default: --rsip-namespace
+	Name      string `json:"name"`
+	Key       string `json:"key,omitempty"` // default: --secret-key
+}
+
+// entrySet holds declared cluster entries (inventory file, CMDB) and enqueues the ones that
+// are added, changed or removed. label links generated RSIPs back to their entry.
+type entrySet struct {
+	label    string
+	triggers chan event.GenericEvent
+
+	mu      sync.RWMutex
+	entries map[string]inventoryEntry
+}
+
+func newEntrySet(label string) *entrySet {
+	return &entrySet{
+		label:    label,
+		triggers: make(chan event.GenericEvent, 1024),
+		entries:  map[string]inventoryEntry{},
+	}
+}
+
+// get returns the current entry for name.
+func (s *entrySet) get(name string) (inventoryEntry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	e, ok := s.entries[name]
+	return e, ok
+}
+
+// replace swaps in next and enqueues every entry whose definition changed or disappeared.
+func (s *entrySet) replace(next map[string]inventoryEntry) (changed int) {
+	s.mu.Lock()
+	prev := s.entries
+	s.entries = next
+	s.mu.Unlock()
+
+	for name, e := range next {
+		if p, ok := prev[name]; !ok || !reflect.DeepEqual(p, e) {
+			s.enqueue(name)
+			changed++
+		}
+	}
+	for name := range prev {
+		if _, ok := next[name]; !ok {
+			s.enqueue(name)
+			changed++
+		}
+	}
+	return changed
+}
+
+// enqueueStale enqueues RSIPs carrying the set's label whose entry no longer exists,
+// e.g. entries removed while the controller was not running.
+func (s *entrySet) enqueueStale(ctx context.Context, reader client.Reader) error {
+	has, err := labels.NewRequirement(s.label, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := reader.List(ctx, &list,
+		managedRSIPs(labels.NewSelector().Add(*has)),
+	); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		name := list.Items[i].GetLabels()[s.label]
+		if _, ok := s.get(name); !ok {
+			s.enqueue(name)
+		}
+	}
+	return nil
+}
+
+// secretToEntries maps a Secret event to the entries referencing that Secret (an empty
+// secretRef namespace means defaultNS), so a rotated kubeconfig or a key added later is
+// picked up without waiting for the retry.
+func (s *entrySet) secretToEntries(defaultNS string) handler.MapFunc {
+	return func(_ context.Context, obj client.Object) []reconcile.Request {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		var reqs []reconcile.Request
+		for name, e := range s.entries {
+			ns := e.SecretRef.Namespace
+			if ns == "" {
+				ns = defaultNS
+			}
+			if ns == obj.GetNamespace() && e.SecretRef.Name == obj.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+			}
+		}
+		return reqs
+	}
+}
+
+func (s *entrySet) enqueue(name string) {
+	obj := &corev1.Secret{} // carrier only; the request is keyed by entry name
+	obj.SetName(name)
+	s.triggers <- event.GenericEvent{Object: obj}
+}
+
+// inventoryWatcher loads --inventory-file and reloads it on change.
+type inventoryWatcher struct {
+	APIReader client.Reader
+	Opts      Options
+	Entries   *entrySet
+}
+
+func newInventoryWatcher(r client.Reader, opts Options) *inventoryWatcher {
+	return &inventoryWatcher{
+		APIReader: r,
+		Opts:      opts,
+		Entries:   newEntrySet(inventoryEntryLabel),
+	}
+}
+
+// Start implements manager.Runnable.
+func (w *inventoryWatcher) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("inventory").WithValues("file", w.Opts.InventoryFile)
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("inventory watcher: %w", err)
+	}
+	defer fsw.Close()
+	// watch the directory: editors and ConfigMap mounts replace the file rather than write it
+	if err := fsw.Add(filepath.Dir(w.Opts.InventoryFile)); err != nil {
+		return fmt.Errorf("watch %s: %w", filepath.Dir(w.Opts.InventoryFile), err)
+	}
+
+	staleChecked := false
+	load := func() {
+		if err := w.reload(log); err != nil {
+			log.Error(err, "inventory load failed; keeping previous entries")
+			return
+		}
+		// only after a successful load, or every generated RSIP would look stale
+		if !staleChecked {
+			if err := w.Entries.enqueueStale(ctx, w.APIReader); err != nil {
+				log.Error(err, "listing inventory RSIPs failed")
+				return
+			}
+			staleChecked = true
+		}
+	}
+	load()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-fsw.Events:
+			if !ok {
+				return nil
+			}
+			log.V(1).Info("inventory directory changed", "event", ev.String())
+			debounce = time.After(inventoryDebounce)
+		case err, ok := <-fsw.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error(err, "inventory watch error")
+		case <-debounce:
+			debounce = nil
+			load()
+		}
+	}
+}
+
+// reload parses the file and hands the entries to the set. An invalid file leaves the
+// previous entries in place.
+func (w *inventoryWatcher) reload(log logr.Logger) error {
+	raw, err := os.ReadFile(w.Opts.InventoryFile)
+	if err != nil {
+		return err
+	}
+	next, err := parseInventory(raw)
+	if err != nil {
+		return err
+	}
+	changed := w.Entries.replace(next)
+	log.Info("inventory loaded", "entries", len(next), "changed", changed)
+	return nil
+}
+
+// parseInventory strictly decodes and validates the inventory file.
+func parseInventory(raw []byte) (map[string]inventoryEntry, error) {
+	var f inventoryFile
+	if err := yaml.UnmarshalStrict(raw, &f); err != nil {
+		return nil, fmt.Errorf("parse inventory: %w", err)
+	}
+	out := make(map[string]inventoryEntry, len(f.Clusters))
+	for i, e := range f.Clusters {
+		if errs := validation.IsDNS1123Label(e.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("inventory entry %d: invalid name %q: %v", i, e.Name, errs)
+		}
+		if _, dup := out[e.Name]; dup {
+			return nil, fmt.Errorf("inventory entry %d: duplicate name %q", i, e.Name)
+		}
+		if e.SecretRef.Name == "" {
+			return nil, fmt.Errorf("inventory entry %q: secretRef.name is required", e.Name)
+		}
+		out[e.Name] = e
+	}
+	return out, nil
+}
+
+// InventoryReconciler generates RSIPs from declared entries (--inventory-file, CMDB).
+type InventoryReconciler struct {
+	*SecretMirrorReconciler
+	Entries *entrySet
+}
+
+func (r *InventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("rsip").WithValues(r.kind(), req.Name)
+
+	entry, ok := r.Entries.get(req.Name)
+	if !ok {
+		log.V(1).Info("entry removed; ensuring cleanup")
+		return reconcile.Result{}, r.ensureInventoryRSIPAbsence(ctx, req.Name)
+	}
+
+	secretNN := types.NamespacedName{Namespace: entry.SecretRef.Namespace, Name: entry.SecretRef.Name}
+	if secretNN.Namespace == "" {
+		secretNN.Namespace = r.Opts.RSIPNamespace
+	}
+	secretKey := entry.SecretRef.Key
+	if secretKey == "" {
+		secretKey = r.Opts.SecretKey
+	}
+	var sec corev1.Secret
+	if err := r.Get(ctx, secretNN, &sec); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		log.Info("kubeconfig secret not found", "secret", secretNN.String())
+		if err := r.ensureInventoryRSIPAbsence(ctx, req.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: inventorySecretRetry}, nil
+	}
+	if _, ok := sec.Data[secretKey]; !ok {
+		log.Info("secret missing kubeconfig key; skipping", "secret", secretNN.String(), "key", secretKey)
+		return reconcile.Result{RequeueAfter: inventorySecretRetry}, nil
+	}
+
+	// labels declared in the file win over the Secret's
+	srcLabels := maps.Clone(sec.Labels)
+	if srcLabels == nil {
+		srcLabels = map[string]string{}
+	}
+	maps.Copy(srcLabels, entry.Labels)
+
+	rsip, _, lbls, dv := r.rsipSkeleton(log, rsipInput{
+		Name:        entry.Name,
+		Namespace:   secretNN.Namespace,
+		Labels:      srcLabels,
+		Annotations: entry.Annotations,
+		RefLabels: map[string]string{
+			r.Entries.label:               entry.Name,
+			"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
+			"mirror.fluxcd.io/secretName": secretNN.Name,
+			"mirror.fluxcd.io/secretKey":  secretKey,
+		},
+		RefValues: map[string]any{
+			"kubeSecretName": secretNN.Name,
+			"kubeSecretKey":  secretKey,
+			"kubeSecretNS":   secretNN.Namespace,
+		},
+	})
+
+	desired := newRSIP(rsip.Namespace, rsip.Name, lbls, dv)
+	if err := r.applyRSIP(ctx, log, &sec, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recordServer(log, lbls, &sec, secretKey)
+	return reconcile.Result{}, r.pruneRSIPs(ctx, log, lbls, rsip)
+}
+
+func (r *InventoryReconciler) ensureInventoryRSIPAbsence(ctx context.Context, name string) error {
+	return r.deleteRSIPsFor(ctx, r.kind(), types.NamespacedName{Name: name},
+		labels.SelectorFromSet(labels.Set{r.Entries.label: name}))
+}
+
+// kind names the entry source in logs ("inventoryEntry", "cmdbEntry").
+func (r *InventoryReconciler) kind() string {
+	return strings.TrimPrefix(r.Entries.label, "mirror.fluxcd.io/")
+}
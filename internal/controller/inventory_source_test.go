@@ -0,0 +1,92 @@
+// internal/controller/inventory_source_test.go
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fixedRSIPs is a client.Reader that lists the given RSIPs.
+type fixedRSIPs []unstructured.Unstructured
+
+func (fixedRSIPs) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return nil
+}
+
+func (f fixedRSIPs) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	if ul, ok := list.(*unstructured.UnstructuredList); ok {
+		ul.Items = append(ul.Items, f...)
+	}
+	return nil
+}
+
+func inventoryRSIP(entry string) unstructured.Unstructured {
+	var u unstructured.Unstructured
+	u.SetGroupVersionKind(rsipGVK)
+	u.SetName("inputs-" + entry)
+	u.SetLabels(map[string]string{"mirror.fluxcd.io/managed": "true", inventoryEntryLabel: entry})
+	return u
+}
+
+// triggered collects the entry names enqueued within wait.
+func triggered(s *entrySet, wait time.Duration) map[string]bool {
+	got := map[string]bool{}
+	timeout := time.After(wait)
+	for {
+		select {
+		case ev := <-s.triggers:
+			got[ev.Object.GetName()] = true
+		case <-timeout:
+			return got
+		}
+	}
+}
+
+func TestInventoryWatcherKeepsRSIPsUntilFirstLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string // empty: no file
+	}{
+		{name: "missing file"},
+		{name: "invalid file", initial: "clusters:\n  - name: dev-1\n    owner: x\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "inventory.yaml")
+			if tt.initial != "" {
+				if err := os.WriteFile(file, []byte(tt.initial), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			w := newInventoryWatcher(fixedRSIPs{inventoryRSIP("dev-1")}, Options{InventoryFile: file})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- w.Start(ctx) }()
+			t.Cleanup(func() {
+				cancel()
+				<-done
+			})
+
+			// without a loaded file dev-1 must not be enqueued, or its RSIP would be deleted
+			if got := triggered(w.Entries, 2*inventoryDebounce); len(got) != 0 {
+				t.Fatalf("enqueued %v before the inventory loaded", got)
+			}
+
+			// the first successful load enqueues the new entry and the RSIP whose entry is gone
+			if err := os.WriteFile(file, []byte("clusters:\n  - name: dev-2\n    secretRef:\n      name: dev-2-kubeconfig\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got := triggered(w.Entries, 4*inventoryDebounce)
+			if !got["dev-1"] || !got["dev-2"] {
+				t.Fatalf("enqueued %v after a valid load, want dev-1 and dev-2", got)
+			}
+		})
+	}
+}
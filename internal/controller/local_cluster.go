@@ -0,0 +1,101 @@
+// internal/controller/local_cluster.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// localClusterLabel marks the synthesized RSIP for the management cluster itself.
+	localClusterLabel = "mirror.fluxcd.io/local"
+	// localClusterResync is how often the local RSIP is re-applied (repairs manual edits).
+	localClusterResync = 2 * time.Minute
+)
+
+// localClusterRSIP keeps one RSIP for the cluster the controller runs in, the equivalent of
+// Argo CD's in-cluster destination. There is no kubeconfig Secret: kubeSecretName is empty
+// and inCluster is true, so templates can omit kubeConfig.
+type localClusterRSIP struct {
+	*SecretMirrorReconciler
+}
+
+// Start implements manager.Runnable.
+func (l *localClusterRSIP) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("rsip").WithValues("localCluster", l.Opts.LocalClusterName)
+	runEvery(ctx, localClusterResync, func(ctx context.Context) {
+		if err := l.apply(ctx, log); err != nil {
+			log.Error(err, "local cluster RSIP sync failed")
+		}
+	})
+	return nil
+}
+
+func (l *localClusterRSIP) apply(ctx context.Context, log logr.Logger) error {
+	rsip, _, lbls, dv := l.rsipSkeleton(log, rsipInput{
+		Name:      l.Opts.LocalClusterName,
+		Namespace: l.Opts.RSIPNamespace,
+		Labels:    l.Opts.LocalClusterLabels,
+		RefLabels: map[string]string{localClusterLabel: "true"},
+		RefValues: map[string]any{
+			"kubeSecretName": "",
+			"kubeSecretKey":  "",
+			"kubeSecretNS":   "",
+			"inCluster":      true,
+		},
+	})
+	// configured labels and values are published as-is; the computed ones win
+	for k, v := range l.Opts.LocalClusterLabels {
+		if _, set := lbls[k]; !set {
+			lbls[k] = v
+		}
+	}
+	for k, v := range l.Opts.LocalClusterValues {
+		if _, set := dv[k]; !set {
+			dv[k] = v
+		}
+	}
+
+	desired := newRSIP(rsip.Namespace, rsip.Name, lbls, dv)
+	// no source object to record events on; the RSIP itself stands in
+	if err := l.applyRSIP(ctx, log, desired, desired); err != nil {
+		return err
+	}
+	// a renamed or moved local cluster (name, template or prefix change) leaves the old RSIP behind
+	l.State.retain(lbls, rsip)
+	return sweepLocalRSIPs(ctx, log, l.APIReader, l.Client, rsip)
+}
+
+// sweepLocalRSIPs deletes local cluster RSIPs in any namespace other than
+// keep (the zero value deletes all, used when the local cluster RSIP is disabled).
+func sweepLocalRSIPs(ctx context.Context, log logr.Logger, reader client.Reader, writer client.Client, keep types.NamespacedName) error {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := reader.List(ctx, &list,
+		managedRSIPs(labels.SelectorFromSet(labels.Set{localClusterLabel: "true"})),
+	); err != nil {
+		return fmt.Errorf("list local cluster RSIPs: %w", err)
+	}
+	for i := range list.Items {
+		rsip := &list.Items[i]
+		if client.ObjectKeyFromObject(rsip) == keep {
+			continue
+		}
+		if err := writer.Delete(ctx, rsip); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted stale local cluster RSIP", "name", rsip.GetName(), "ns", rsip.GetNamespace())
+	}
+	return nil
+}
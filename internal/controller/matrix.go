@@ -0,0 +1,143 @@
+// internal/controller/matrix.go
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// matrixAnnotation on a Secret names the ConfigMap in --matrix-namespace it is crossed with.
+	matrixAnnotation = "fcg.io/matrix"
+	// matrixEntriesKey is the ConfigMap key holding the list entries.
+	matrixEntriesKey = "entries.yaml"
+	// matrixLabel and matrixEntryLabel name the list and entry of a matrix RSIP.
+	matrixLabel      = "mirror.fluxcd.io/matrix"
+	matrixEntryLabel = "mirror.fluxcd.io/matrixEntry"
+)
+
+// errMatrixUnavailable is returned by matrixVariants when the ConfigMap is missing or invalid;
+// the Secret's current matrix RSIPs are kept until it is fixed (its watch requeues the Secret).
+var errMatrixUnavailable = errors.New("matrix unavailable")
+
+// matrixFile is the format of the entries.yaml key of a matrix ConfigMap.
+type matrixFile struct {
+	Entries []matrixEntry `json:"entries"`
+}
+
+// matrixEntry is one element of the list a cluster is crossed with (a tenant, a region shard).
+type matrixEntry struct {
+	Name   string            `json:"name"`
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// parseMatrix strictly decodes and validates a matrix list.
+func parseMatrix(raw []byte) ([]matrixEntry, error) {
+	var f matrixFile
+	if err := yaml.UnmarshalStrict(raw, &f); err != nil {
+		return nil, fmt.Errorf("parse matrix: %w", err)
+	}
+	seen := map[string]bool{}
+	for i, e := range f.Entries {
+		if errs := validation.IsDNS1123Label(e.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("matrix entry %d: invalid name %q: %v", i, e.Name, errs)
+		}
+		if seen[e.Name] {
+			return nil, fmt.Errorf("matrix entry %d: duplicate name %q", i, e.Name)
+		}
+		seen[e.Name] = true
+	}
+	return f.Entries, nil
+}
+
+// matrixVariants returns one variant per entry of the matrix ConfigMap sec references; ok is
+// false when sec is not crossed with a list. A missing or invalid ConfigMap is reported as an
+// event and returns errMatrixUnavailable.
+func (r *SecretMirrorReconciler) matrixVariants(ctx context.Context, sec *corev1.Secret) (variants []rsipVariant, ok bool, err error) {
+	name := sec.Annotations[matrixAnnotation]
+	if r.Opts.MatrixNamespace == "" || name == "" {
+		return nil, false, nil
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.Opts.MatrixNamespace, Name: name}, &cm); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return nil, true, err
+		}
+		// like an invalid list: keep the current combinations until the ConfigMap is back
+		r.Recorder.Eventf(sec, corev1.EventTypeWarning, "MatrixNotFound",
+			"matrix ConfigMap %s/%s not found", r.Opts.MatrixNamespace, name)
+		return nil, true, fmt.Errorf("%w: ConfigMap %s/%s not found", errMatrixUnavailable, r.Opts.MatrixNamespace, name)
+	}
+	entries, err := parseMatrix([]byte(cm.Data[matrixEntriesKey]))
+	if err != nil {
+		// keep the current combinations until the list is fixed
+		r.Recorder.Eventf(sec, corev1.EventTypeWarning, "MatrixInvalid",
+			"matrix ConfigMap %s/%s: %v", cm.Namespace, cm.Name, err)
+		return nil, true, fmt.Errorf("%w: %w", errMatrixUnavailable, err)
+	}
+	for _, e := range entries {
+		variants = append(variants, rsipVariant{
+			suffixes: []string{e.Name},
+			labels:   map[string]string{matrixLabel: name, matrixEntryLabel: e.Name},
+			ids:      map[string]any{"matrix": name, "matrixEntry": e.Name},
+			values:   e.Values,
+		})
+	}
+	return variants, true, nil
+}
+
+// matrixRSIPs returns the matrix RSIPs of the source identified by lbls.
+func (r *SecretMirrorReconciler) matrixRSIPs(ctx context.Context, lbls map[string]string) ([]types.NamespacedName, error) {
+	sel, ok := sourceRef(lbls)
+	if !ok {
+		return nil, nil
+	}
+	has, err := labels.NewRequirement(matrixLabel, selection.Exists, nil)
+	if err != nil {
+		return nil, err
+	}
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := r.APIReader.List(ctx, &list, managedRSIPs(sel.Add(*has))); err != nil {
+		return nil, fmt.Errorf("list matrix RSIPs: %w", err)
+	}
+	out := make([]types.NamespacedName, 0, len(list.Items))
+	for i := range list.Items {
+		out = append(out, client.ObjectKeyFromObject(&list.Items[i]))
+	}
+	return out, nil
+}
+
+// matrixToSecrets re-reconciles the Secrets crossed with a matrix ConfigMap.
+func matrixToSecrets(c client.Reader) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list corev1.SecretList
+		if err := c.List(ctx, &list); err != nil {
+			ctrl.Log.WithName("matrix").Error(err, "list secrets for matrix ConfigMap failed",
+				"configMap", client.ObjectKeyFromObject(obj).String())
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			if list.Items[i].Annotations[matrixAnnotation] == obj.GetName() {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+			}
+		}
+		return reqs
+	}
+}
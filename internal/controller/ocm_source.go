@@ -0,0 +1,190 @@
+// internal/controller/ocm_source.go
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var managedClusterGVK = schema.GroupVersionKind{
+	Group:   "cluster.open-cluster-management.io",
+	Version: "v1",
+	Kind:    "ManagedCluster",
+}
+
+const (
+	// managedClusterLabel links an RSIP to the ManagedCluster it was generated for.
+	managedClusterLabel = "mirror.fluxcd.io/managedCluster"
+	// claimLabelPrefix is the label domain used for copied ClusterClaims.
+	claimLabelPrefix = "claim.fcg.io/"
+	// ocmSecretRetry is how often a ManagedCluster without its kubeconfig Secret is retried.
+	ocmSecretRetry = time.Minute
+)
+
+// ManagedClusterReconciler generates RSIPs from Open Cluster Management ManagedClusters,
+// pairing each with a kubeconfig Secret found via --ocm-secret-template.
+type ManagedClusterReconciler struct {
+	*SecretMirrorReconciler
+}
+
+func (r *ManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("rsip").WithValues("managedCluster", req.Name)
+
+	var mc unstructured.Unstructured
+	mc.SetGroupVersionKind(managedClusterGVK)
+	if err := r.Get(ctx, req.NamespacedName, &mc); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.ensureManagedClusterRSIPAbsence(ctx, req.Name)
+	}
+	if !hasTrueCondition(&mc, "ManagedClusterConditionAvailable") {
+		log.V(1).Info("managed cluster not available; ensuring cleanup")
+		return reconcile.Result{}, r.ensureManagedClusterRSIPAbsence(ctx, req.Name)
+	}
+
+	secretNN, err := r.ocmSecretRef(&mc)
+	if err != nil {
+		r.Recorder.Eventf(&mc, corev1.EventTypeWarning, "SecretRefFailed", "%v", err)
+		log.Info("kubeconfig secret reference failed; ensuring cleanup", "reason", err.Error())
+		return reconcile.Result{}, r.ensureManagedClusterRSIPAbsence(ctx, req.Name)
+	}
+	var sec corev1.Secret
+	if err := r.Get(ctx, secretNN, &sec); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		log.V(1).Info("kubeconfig secret not found yet", "secret", secretNN.String())
+		if err := r.ensureManagedClusterRSIPAbsence(ctx, req.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: ocmSecretRetry}, nil
+	}
+	secretKey := r.Opts.SecretKey
+	if _, ok := sec.Data[secretKey]; !ok {
+		log.Info("secret missing kubeconfig key; ensuring cleanup", "secret", secretNN.String(), "key", secretKey)
+		if err := r.ensureManagedClusterRSIPAbsence(ctx, req.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: ocmSecretRetry}, nil
+	}
+
+	// ManagedCluster labels win over the Secret's
+	srcLabels := maps.Clone(sec.Labels)
+	if srcLabels == nil {
+		srcLabels = map[string]string{}
+	}
+	maps.Copy(srcLabels, mc.GetLabels())
+
+	rsip, _, lbls, dv := r.rsipSkeleton(log, rsipInput{
+		Name:        mc.GetName(),
+		Namespace:   sec.Namespace,
+		Labels:      srcLabels,
+		Annotations: mc.GetAnnotations(),
+		RefLabels: map[string]string{
+			managedClusterLabel:           mc.GetName(),
+			"mirror.fluxcd.io/secretNS":   sec.Namespace,
+			"mirror.fluxcd.io/secretName": sec.Name,
+			"mirror.fluxcd.io/secretKey":  secretKey,
+		},
+		RefValues: map[string]any{
+			"kubeSecretName": sec.Name,
+			"kubeSecretKey":  secretKey,
+			"kubeSecretNS":   sec.Namespace,
+		},
+	})
+	applyNamedValues(lbls, dv, claimLabelPrefix, "claim-", nameValues(&mc, "status", "clusterClaims"))
+
+	desired := newRSIP(rsip.Namespace, rsip.Name, lbls, dv)
+	if err := r.applyRSIP(ctx, log, &mc, desired); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.recordServer(log, lbls, &sec, secretKey)
+	return reconcile.Result{}, r.pruneRSIPs(ctx, log, lbls, rsip)
+}
+
+func (r *ManagedClusterReconciler) ensureManagedClusterRSIPAbsence(ctx context.Context, name string) error {
+	return r.deleteRSIPsFor(ctx, "managedCluster", types.NamespacedName{Name: name},
+		labels.SelectorFromSet(labels.Set{managedClusterLabel: name}))
+}
+
+// secretToManagedClusters maps a Secret event to the ManagedClusters whose --ocm-secret-template
+// names that Secret, so a deleted or rotated kubeconfig is picked up without waiting for the retry.
+func (r *ManagedClusterReconciler) secretToManagedClusters(ctx context.Context, obj client.Object) []reconcile.Request {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: managedClusterGVK.Group, Version: managedClusterGVK.Version, Kind: managedClusterGVK.Kind + "List",
+	})
+	if err := r.List(ctx, &list); err != nil {
+		ctrl.Log.WithName("rsip").Error(err, "listing ManagedClusters failed", "secret", client.ObjectKeyFromObject(obj).String())
+		return nil
+	}
+	secretNN := client.ObjectKeyFromObject(obj)
+	var reqs []reconcile.Request
+	for i := range list.Items {
+		if nn, err := r.ocmSecretRef(&list.Items[i]); err == nil && nn == secretNN {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Name: list.Items[i].GetName()}})
+		}
+	}
+	return reqs
+}
+
+// ocmSecretRef renders --ocm-secret-template ("namespace/name") for a ManagedCluster.
+func (r *ManagedClusterReconciler) ocmSecretRef(mc *unstructured.Unstructured) (types.NamespacedName, error) {
+	var buf bytes.Buffer
+	if err := r.Opts.OCMSecretTemplate.Execute(&buf, map[string]any{
+		"name":        mc.GetName(),
+		"labels":      mc.GetLabels(),
+		"annotations": mc.GetAnnotations(),
+	}); err != nil {
+		return types.NamespacedName{}, fmt.Errorf("ocm-secret-template: %w", err)
+	}
+	ns, name, ok := strings.Cut(strings.TrimSpace(buf.String()), "/")
+	if !ok || ns == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("ocm-secret-template rendered %q, want namespace/name", buf.String())
+	}
+	return types.NamespacedName{Namespace: ns, Name: name}, nil
+}
+
+// nameValues returns a [{name, value}] list (ClusterClaims, ClusterProfile properties) as name -> value.
+func nameValues(u *unstructured.Unstructured, fields ...string) map[string]string {
+	out := map[string]string{}
+	items, _, _ := unstructured.NestedSlice(u.Object, fields...)
+	for _, c := range items {
+		m, ok := c.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name := toString(m["name"]); name != "" {
+			out[name] = toString(m["value"])
+		}
+	}
+	return out
+}
+
+// applyNamedValues publishes each entry as a labelPrefix+name label (when valid) and a
+// camelCased valuePrefix+name defaultValue.
+func applyNamedValues(lbls map[string]string, dv map[string]any, labelPrefix, valuePrefix string, entries map[string]string) {
+	for name, value := range entries {
+		if len(validation.IsQualifiedName(labelPrefix+name)) == 0 {
+			if lv := sanitizeLabelValue(value); lv != "" {
+				lbls[labelPrefix+name] = lv
+			}
+		}
+		dv[toCamel(valuePrefix+name)] = value
+	}
+}
@@ -2,11 +2,13 @@ package controller
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 	"text/template" 
 
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // Options carries raw flag values plus parsed/derived forms used by the controller.
@@ -20,6 +22,10 @@ type Options struct {
 	RSIPNameTemplateStr string
 	RSIPNameTemplate    *template.Template
 
+	// Per-source RSIP namespace (optional; falls back to RSIPNamespace)
+	RSIPNamespaceTemplateStr string
+	RSIPNamespaceTemplate    *template.Template
+
 	// Selectors / filters (raw strings for flags)
 	LabelSelectorStr          string
 	NamespaceLabelSelectorStr string
@@ -37,6 +43,112 @@ type Options struct {
 	// Tuning
 	MaxConcurrent    int
 	CacheSyncTimeout time.Duration
+
+	// Reachability probing (optional)
+	ProbeEnabled              bool
+	ProbeInterval             time.Duration
+	ProbeTimeout              time.Duration
+	ProbeUnreachablePolicy    string // "pause" or "withdraw"
+	ProbeUnreachableThreshold time.Duration
+
+	// Capability discovery (optional)
+	CapabilitiesEnabled   bool
+	CapabilitiesCSV       string            // raw: "gateway-api=gateway.networking.k8s.io,istio=networking.istio.io/virtualservices"
+	Capabilities          map[string]string // parsed: capability name -> "group" or "group/resource"
+	CapabilityGPUResource string
+	CapabilityInterval    time.Duration
+
+	// Self-described metadata read from a ConfigMap inside each target cluster (optional)
+	FleetInfoConfigMap string // "namespace/name"; empty disables
+	FleetInfoKeysCSV   string
+	FleetInfoKeys      []string
+	FleetInfoInterval  time.Duration
+
+	// Argo CD cluster Secrets as an additional source (optional)
+	ArgoCDSourceEnabled   bool
+	ArgoCDNamespace       string // where Argo CD cluster Secrets live
+	ArgoCDTargetNamespace string // where synthesized Flux kubeconfig Secrets are written
+
+	// Argo CD cluster Secrets as an additional output beside RSIPs (optional)
+	ArgoCDOutputEnabled   bool
+	ArgoCDOutputNamespace string
+
+	// Cluster API Clusters as a source (optional)
+	CAPISourceEnabled bool
+
+	// vCluster Platform VirtualClusterInstance enrichment (optional)
+	VCIEnrichmentEnabled bool
+	VCISleepPolicy       string // "mark" or "withdraw"
+
+	// Flux workload-identity kubeconfig ConfigMaps as a source (optional)
+	ConfigMapLabelSelectorStr string
+	ConfigMapSelector         labels.Selector // nil when disabled
+
+	// Open Cluster Management ManagedClusters as a source (optional)
+	OCMSourceEnabled     bool
+	OCMSecretTemplateStr string             // renders "namespace/name" of the kubeconfig Secret
+	OCMSecretTemplate    *template.Template // parsed in main
+
+	// SIG-Multicluster ClusterProfiles as a source and/or output (optional)
+	ClusterProfileSourceEnabled   bool
+	ClusterProfileOutputEnabled   bool
+	ClusterProfileOutputNamespace string
+
+	// Static cluster inventory file as a source (optional)
+	InventoryFile string // empty disables
+
+	// External CMDB polled over HTTP (optional)
+	CMDBURL             string // empty disables
+	CMDBBearerTokenFile string
+	CMDBInterval        time.Duration
+	CMDBGenerate        bool // also generate RSIPs for CMDB entries with a secretRef
+
+	// Remote management clusters whose kubeconfig Secrets are copied into the hub (optional)
+	RemoteKubeconfigsCSV  string            // raw: "eu=/etc/remotes/eu,us=/etc/remotes/us"
+	RemoteKubeconfigs     map[string]string // parsed: source cluster name -> kubeconfig path
+	RemoteTargetNamespace string
+
+	// Synthesized RSIP for the management cluster itself (optional)
+	LocalClusterEnabled   bool
+	LocalClusterName      string
+	LocalClusterLabelsCSV string // raw: "env=mgmt,tier=hub"
+	LocalClusterLabels    map[string]string
+	LocalClusterValuesCSV string // raw: "region=eu-west-1"
+	LocalClusterValues    map[string]string
+
+	// Replicate kubeconfig Secrets into the RSIP namespace (optional)
+	ReplicateKubeconfigs bool
+
+	// Fan-out of each Secret's RSIP into several namespaces (optional)
+	FanoutNamespacesCSV        string // raw: "team-a,team-b"
+	FanoutNamespaces           []string
+	FanoutNamespaceSelectorStr string
+	FanoutNamespaceSelector    labels.Selector // nil when unset
+
+	// One RSIP per enabled app label instead of one per cluster (optional)
+	AppFanout      bool
+	AppLabelPrefix string
+
+	// Namespace of the ConfigMaps Secrets are crossed with via fcg.io/matrix (optional)
+	MatrixNamespace string
+
+	// Maintain spec.inputs of ResourceSets annotated fcg.io/inputs-selector (optional)
+	ResourceSetInputsEnabled bool
+
+	// Request ResourceSet reconciliation when their RSIPs change (optional)
+	ResourceSetTriggerEnabled  bool
+	ResourceSetTriggerInterval time.Duration
+
+	// Roll HelmRelease/Kustomization Ready conditions up per cluster (optional)
+	RolloutStatusEnabled   bool
+	RolloutStatusConfigMap string // summary ConfigMap in RSIPNamespace; empty disables it
+
+	// Fleet inventory summary ConfigMap (optional)
+	FleetSummaryConfigMap string
+	FleetSummaryNamespace string // default: RSIPNamespace
+
+	// Read-only HTTP inventory API over the in-memory fleet state (optional)
+	InventoryAPIBindAddress string
 }
 
 // FillAndValidate parses raw strings into selectors/slices, applies defaults, and validates.
@@ -63,6 +175,24 @@ func (o *Options) FillAndValidate() error {
 	if o.CacheSyncTimeout <= 0 {
 		o.CacheSyncTimeout = 2 * time.Minute
 	}
+	if o.ProbeInterval <= 0 {
+		o.ProbeInterval = time.Minute
+	}
+	if o.ProbeTimeout <= 0 {
+		o.ProbeTimeout = 10 * time.Second
+	}
+	if o.ProbeUnreachablePolicy == "" {
+		o.ProbeUnreachablePolicy = ProbePolicyPause
+	}
+	if o.ProbeUnreachableThreshold < 0 {
+		o.ProbeUnreachableThreshold = 0
+	}
+	switch o.ProbeUnreachablePolicy {
+	case ProbePolicyPause, ProbePolicyWithdraw:
+	default:
+		return fmt.Errorf("invalid probe unreachable policy %q (want %q or %q)",
+			o.ProbeUnreachablePolicy, ProbePolicyPause, ProbePolicyWithdraw)
+	}
 
 	// Parse selectors
 	if o.LabelSelectorStr == "" {
@@ -85,6 +215,126 @@ func (o *Options) FillAndValidate() error {
 		o.NamespaceSelector = nsSel
 	}
 
+	if o.CapabilityInterval <= 0 {
+		o.CapabilityInterval = 10 * time.Minute
+	}
+	caps, err := parseCapabilities(o.CapabilitiesCSV)
+	if err != nil {
+		return err
+	}
+	o.Capabilities = caps
+
+	if o.FleetInfoInterval <= 0 {
+		o.FleetInfoInterval = 10 * time.Minute
+	}
+	o.FleetInfoKeys = splitNonEmpty(o.FleetInfoKeysCSV)
+	if o.FleetInfoConfigMap != "" {
+		if ns, name, ok := strings.Cut(o.FleetInfoConfigMap, "/"); !ok || ns == "" || name == "" {
+			return fmt.Errorf("invalid fleet info configmap %q (want namespace/name)", o.FleetInfoConfigMap)
+		}
+		if len(o.FleetInfoKeys) == 0 {
+			return fmt.Errorf("fleet info configmap %q set but no keys allowed (set --fleet-info-keys)", o.FleetInfoConfigMap)
+		}
+	}
+
+	if o.ArgoCDNamespace == "" {
+		o.ArgoCDNamespace = "argocd"
+	}
+	if o.ArgoCDTargetNamespace == "" {
+		o.ArgoCDTargetNamespace = o.RSIPNamespace
+	}
+	if o.ArgoCDOutputNamespace == "" {
+		o.ArgoCDOutputNamespace = "argocd"
+	}
+
+	if o.ClusterProfileOutputNamespace == "" {
+		o.ClusterProfileOutputNamespace = "fleet-system"
+	}
+
+	if o.CMDBInterval <= 0 {
+		o.CMDBInterval = 5 * time.Minute
+	}
+	if o.CMDBURL != "" {
+		if u, err := url.Parse(o.CMDBURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid cmdb url %q (want http(s)://host/path)", o.CMDBURL)
+		}
+	} else if o.CMDBGenerate {
+		return fmt.Errorf("--cmdb-generate requires --cmdb-url")
+	}
+
+	remotes, err := parseRemoteKubeconfigs(o.RemoteKubeconfigsCSV)
+	if err != nil {
+		return err
+	}
+	o.RemoteKubeconfigs = remotes
+	if o.RemoteTargetNamespace == "" {
+		o.RemoteTargetNamespace = o.RSIPNamespace
+	}
+
+	if o.LocalClusterName == "" {
+		o.LocalClusterName = "in-cluster"
+	}
+	if o.LocalClusterLabels, err = parseKeyValues(o.LocalClusterLabelsCSV, true); err != nil {
+		return fmt.Errorf("invalid local cluster labels: %w", err)
+	}
+	if o.LocalClusterValues, err = parseKeyValues(o.LocalClusterValuesCSV, false); err != nil {
+		return fmt.Errorf("invalid local cluster values: %w", err)
+	}
+
+	o.FanoutNamespaces = splitNonEmpty(o.FanoutNamespacesCSV)
+	for _, ns := range o.FanoutNamespaces {
+		if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+			return fmt.Errorf("invalid fan-out namespace %q: %s", ns, strings.Join(errs, "; "))
+		}
+	}
+	if o.FanoutNamespaceSelectorStr != "" {
+		sel, err := labels.Parse(o.FanoutNamespaceSelectorStr)
+		if err != nil {
+			return fmt.Errorf("invalid fan-out namespace selector %q: %w", o.FanoutNamespaceSelectorStr, err)
+		}
+		o.FanoutNamespaceSelector = sel
+	}
+	if o.fanoutEnabled() && o.RSIPNamespaceTemplateStr != "" {
+		return fmt.Errorf("--rsip-namespace-template cannot be combined with fan-out namespaces")
+	}
+
+	if o.AppLabelPrefix == "" {
+		o.AppLabelPrefix = "flux-app/"
+	}
+	if o.ResourceSetTriggerInterval <= 0 {
+		o.ResourceSetTriggerInterval = 5 * time.Second
+	}
+	if o.FleetSummaryNamespace == "" {
+		o.FleetSummaryNamespace = o.RSIPNamespace
+	}
+	if o.MatrixNamespace != "" {
+		if errs := validation.IsDNS1123Label(o.MatrixNamespace); len(errs) > 0 {
+			return fmt.Errorf("invalid matrix namespace %q: %s", o.MatrixNamespace, strings.Join(errs, "; "))
+		}
+	}
+
+	if o.VCISleepPolicy == "" {
+		o.VCISleepPolicy = VCISleepPolicyMark
+	}
+	switch o.VCISleepPolicy {
+	case VCISleepPolicyMark, VCISleepPolicyWithdraw:
+	default:
+		return fmt.Errorf("invalid vci sleep policy %q (want %q or %q)",
+			o.VCISleepPolicy, VCISleepPolicyMark, VCISleepPolicyWithdraw)
+	}
+
+	if o.ConfigMapLabelSelectorStr != "" {
+		cmSel, err := labels.Parse(o.ConfigMapLabelSelectorStr)
+		if err != nil {
+			return fmt.Errorf("invalid configmap label selector %q: %w", o.ConfigMapLabelSelectorStr, err)
+		}
+		o.ConfigMapSelector = cmSel
+	}
+
+	if o.OCMSourceEnabled && o.OCMSecretTemplate == nil {
+		return fmt.Errorf("ocm source enabled but no --ocm-secret-template")
+	}
+
 	// CSV → slices
 	o.WatchNamespaces = splitNonEmpty(o.WatchNamespacesCSV)
 	o.CopyLabelKeys = splitNonEmpty(o.CopyLabelKeysCSV)
@@ -108,3 +358,67 @@ func splitNonEmpty(csv string) []string {
 	}
 	return out
 }
+
+// parseCapabilities parses "name=group[/resource]" entries into a map.
+func parseCapabilities(csv string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, entry := range splitNonEmpty(csv) {
+		name, target, ok := strings.Cut(entry, "=")
+		name, target = strings.TrimSpace(name), strings.TrimSpace(target)
+		if !ok || name == "" || target == "" {
+			return nil, fmt.Errorf("invalid capability %q (want name=group[/resource])", entry)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid capability name %q: %s", name, strings.Join(errs, "; "))
+		}
+		out[name] = target
+	}
+	return out, nil
+}
+
+// parseRemoteKubeconfigs parses "name=/path/to/kubeconfig" entries into a map.
+func parseRemoteKubeconfigs(csv string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, entry := range splitNonEmpty(csv) {
+		name, path, ok := strings.Cut(entry, "=")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid remote kubeconfig %q (want name=path)", entry)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid remote cluster name %q: %s", name, strings.Join(errs, "; "))
+		}
+		if _, dup := out[name]; dup {
+			return nil, fmt.Errorf("duplicate remote cluster name %q", name)
+		}
+		out[name] = path
+	}
+	return out, nil
+}
+
+// parseKeyValues parses "key=value" entries into a map; asLabels validates them as label keys/values.
+func parseKeyValues(csv string, asLabels bool) (map[string]string, error) {
+	out := map[string]string{}
+	for _, entry := range splitNonEmpty(csv) {
+		k, v, ok := strings.Cut(entry, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid entry %q (want key=value)", entry)
+		}
+		if asLabels {
+			errs := append(validation.IsQualifiedName(k), validation.IsValidLabelValue(v)...)
+			if len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label %q: %s", entry, strings.Join(errs, "; "))
+			}
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// fanoutEnabled reports whether Secrets fan out into several RSIP namespaces.
+func (o Options) fanoutEnabled() bool {
+	return len(o.FanoutNamespaces) > 0 || o.FanoutNamespaceSelector != nil
+}
+
+
@@ -0,0 +1,224 @@
+// internal/controller/prober.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Policies for clusters that stay unreachable past the threshold.
+const (
+	ProbePolicyPause    = "pause"    // keep the RSIP, mark it paused
+	ProbePolicyWithdraw = "withdraw" // delete the RSIP until the cluster is back
+)
+
+// probeLastTimestamp exposes lastProbe, which changes every round and so is kept off the RSIP.
+var probeLastTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "fcg_cluster_probe_last_timestamp_seconds",
+	Help: "Unix time of the last reachability probe of a kubeconfig Secret's cluster.",
+}, []string{"namespace", "secret"})
+
+func init() {
+	metrics.Registry.MustRegister(probeLastTimestamp)
+}
+
+// probeResult is the last known reachability of one cluster.
+type probeResult struct {
+	Healthy        bool
+	ServerVersion  string
+	LastProbe      time.Time
+	UnhealthySince time.Time // zero while healthy
+	Unreachable    bool      // unhealthy for longer than the threshold
+	Err            string
+}
+
+// clusterProber periodically calls /version and /readyz on every selected cluster
+// using the kubeconfig from its Secret and keeps the results in memory.
+type clusterProber struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+	Opts     Options
+
+	// matches reports whether a Secret is a generator source (selector, namespaces).
+	matches func(client.Object) bool
+	// notify asks the RSIP controller to reconcile a Secret after its result changed
+	// (reachability or server version).
+	notify func(types.NamespacedName)
+
+	mu      sync.RWMutex
+	results map[types.NamespacedName]probeResult
+}
+
+func newClusterProber(c client.Client, rec record.EventRecorder, opts Options,
+	matches func(client.Object) bool, notify func(types.NamespacedName)) *clusterProber {
+	return &clusterProber{
+		Client:   c,
+		Recorder: rec,
+		Opts:     opts,
+		matches:  matches,
+		notify:   notify,
+		results:  map[types.NamespacedName]probeResult{},
+	}
+}
+
+// Start implements manager.Runnable.
+func (p *clusterProber) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("probe")
+	runEvery(ctx, p.Opts.ProbeInterval, func(ctx context.Context) {
+		if err := p.probeAll(ctx, log); err != nil {
+			log.Error(err, "probe round failed")
+		}
+	})
+	return nil
+}
+
+// result returns the last probe result for a Secret, if it has been probed.
+func (p *clusterProber) result(nn types.NamespacedName) (probeResult, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	res, ok := p.results[nn]
+	return res, ok
+}
+
+// withdraw reports whether the RSIP for nn must be removed under the withdraw policy.
+func (p *clusterProber) withdraw(nn types.NamespacedName) bool {
+	res, ok := p.result(nn)
+	return ok && res.Unreachable && p.Opts.ProbeUnreachablePolicy == ProbePolicyWithdraw
+}
+
+// apply records the probe result for nn on the RSIP labels and defaultValues.
+func (p *clusterProber) apply(nn types.NamespacedName, lbls map[string]string, dv map[string]any) {
+	res, ok := p.result(nn)
+	if !ok {
+		return // not probed yet
+	}
+	lbls["mirror.fluxcd.io/healthy"] = strconv.FormatBool(res.Healthy)
+	dv["healthy"] = strconv.FormatBool(res.Healthy)
+	if res.ServerVersion != "" {
+		lbls["mirror.fluxcd.io/serverVersion"] = sanitizeLabelValue(res.ServerVersion)
+		dv["serverVersion"] = res.ServerVersion
+	}
+	// written whenever the RSIP is; probeOne does not requeue for it alone, or every RSIP (and
+	// every ResourceSet) would be rewritten each interval. The metric has the exact time.
+	dv["lastProbe"] = res.LastProbe.UTC().Format(time.RFC3339)
+	if res.Unreachable {
+		lbls["mirror.fluxcd.io/paused"] = "true"
+		dv["paused"] = "true"
+	}
+}
+
+func (p *clusterProber) probeAll(ctx context.Context, log logr.Logger) error {
+	seen, err := forEachCluster(ctx, p.Client, p.Opts, p.matches, func(ctx context.Context, sec *corev1.Secret) {
+		p.probeOne(ctx, log, sec)
+	})
+	if err != nil {
+		return err
+	}
+
+	// forget Secrets that are gone or no longer selected
+	p.mu.Lock()
+	for nn := range p.results {
+		if _, ok := seen[nn]; !ok {
+			delete(p.results, nn)
+			probeLastTimestamp.DeleteLabelValues(nn.Namespace, nn.Name)
+		}
+	}
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *clusterProber) probeOne(ctx context.Context, log logr.Logger, sec *corev1.Secret) {
+	nn := client.ObjectKeyFromObject(sec)
+	now := time.Now()
+
+	res := probeResult{LastProbe: now}
+	cfg, err := restConfigFromSecret(sec, kubeconfigKey(sec, p.Opts))
+	if err == nil {
+		res.ServerVersion, err = probeCluster(ctx, cfg, p.Opts.ProbeTimeout)
+	}
+	if err != nil {
+		res.Err = err.Error()
+	} else {
+		res.Healthy = true
+	}
+
+	p.mu.Lock()
+	prev, hadPrev := p.results[nn]
+	if !res.Healthy {
+		res.UnhealthySince = now
+		if hadPrev && !prev.UnhealthySince.IsZero() {
+			res.UnhealthySince = prev.UnhealthySince
+		}
+		res.Unreachable = now.Sub(res.UnhealthySince) >= p.Opts.ProbeUnreachableThreshold
+		if res.ServerVersion == "" && hadPrev {
+			res.ServerVersion = prev.ServerVersion // keep the last known version
+		}
+	}
+	p.results[nn] = res
+	p.mu.Unlock()
+	probeLastTimestamp.WithLabelValues(nn.Namespace, nn.Name).Set(float64(now.Unix()))
+
+	switch {
+	case res.Healthy && hadPrev && !prev.Healthy:
+		p.Recorder.Eventf(sec, corev1.EventTypeNormal, "ClusterReachable",
+			"cluster is reachable again (version %s)", res.ServerVersion)
+		log.Info("cluster reachable again", "secret", nn.String(), "version", res.ServerVersion)
+	case !res.Healthy && (!hadPrev || prev.Healthy):
+		p.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterUnreachable",
+			"cluster probe failed: %s", res.Err)
+		log.Info("cluster unreachable", "secret", nn.String(), "error", res.Err)
+	case res.Unreachable && !prev.Unreachable:
+		p.Recorder.Eventf(sec, corev1.EventTypeWarning, "ClusterUnreachablePastThreshold",
+			"cluster unreachable for more than %s; policy %s", p.Opts.ProbeUnreachableThreshold, p.Opts.ProbeUnreachablePolicy)
+	default:
+		log.V(1).Info("probed cluster", "secret", nn.String(), "healthy", res.Healthy, "version", res.ServerVersion)
+	}
+
+	// only what apply publishes; an unchanged result would rewrite the RSIP for nothing
+	if !hadPrev || res.Healthy != prev.Healthy || res.Unreachable != prev.Unreachable ||
+		res.ServerVersion != prev.ServerVersion {
+		p.notify(nn)
+	}
+}
+
+// probeCluster calls /version and /readyz on the API server behind cfg and
+// returns its git version. Any transport error or a non-"ok" readyz fails the probe.
+func probeCluster(ctx context.Context, cfg *rest.Config, timeout time.Duration) (string, error) {
+	cfg = rest.CopyConfig(cfg)
+	cfg.Timeout = timeout
+
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return "", fmt.Errorf("discovery client: %w", err)
+	}
+	info, err := dc.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("GET /version: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	body, err := dc.RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	if err != nil {
+		return info.GitVersion, fmt.Errorf("GET /readyz: %w", err)
+	}
+	if got := strings.TrimSpace(string(body)); got != "ok" {
+		return info.GitVersion, fmt.Errorf("GET /readyz: unexpected body %q", got)
+	}
+	return info.GitVersion, nil
+}
@@ -0,0 +1,76 @@
+// internal/controller/prober_test.go
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer serves /version and /readyz like a kube-apiserver.
+func fakeAPIServer(t *testing.T, versionStatus int, readyz string, delay time.Duration) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/version", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(versionStatus)
+		_, _ = w.Write([]byte(`{"major":"1","minor":"30","gitVersion":"v1.30.2"}`))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte(readyz))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProbeCluster(t *testing.T) {
+	tests := []struct {
+		name          string
+		versionStatus int
+		readyz        string
+		delay         time.Duration
+		wantVersion   string
+		wantErr       string
+	}{
+		{name: "healthy", versionStatus: http.StatusOK, readyz: "ok", wantVersion: "v1.30.2"},
+		{name: "readyz not ok", versionStatus: http.StatusOK, readyz: "[-]etcd failed", wantVersion: "v1.30.2", wantErr: "unexpected body"},
+		{name: "version fails", versionStatus: http.StatusInternalServerError, readyz: "ok", wantErr: "GET /version"},
+		{name: "readyz times out", versionStatus: http.StatusOK, readyz: "ok", delay: time.Second, wantVersion: "v1.30.2", wantErr: "GET /readyz"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fakeAPIServer(t, tt.versionStatus, tt.readyz, tt.delay)
+
+			version, err := probeCluster(context.Background(), &rest.Config{Host: srv.URL}, 200*time.Millisecond)
+			if version != tt.wantVersion {
+				t.Errorf("version = %q, want %q", version, tt.wantVersion)
+			}
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProbeClusterUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // nothing listens on the address any more
+
+	if _, err := probeCluster(context.Background(), &rest.Config{Host: srv.URL}, 200*time.Millisecond); err == nil {
+		t.Fatal("expected an error for an unreachable server")
+	}
+}
@@ -0,0 +1,90 @@
+// internal/controller/remote.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// remoteWorkers bounds how many target clusters are contacted in parallel.
+	remoteWorkers = 8
+	// remoteTimeout bounds a single request against a target cluster.
+	remoteTimeout = 15 * time.Second
+)
+
+// restConfigFromSecret builds a client config for the target cluster from the kubeconfig stored in sec.Data[key].
+func restConfigFromSecret(sec *corev1.Secret, key string) (*rest.Config, error) {
+	raw, ok := sec.Data[key]
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("secret %s/%s has no kubeconfig under key %q", sec.Namespace, sec.Name, key)
+	}
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parse kubeconfig from secret %s/%s: %w", sec.Namespace, sec.Name, err)
+	}
+	return cfg, nil
+}
+
+// forEachCluster lists the selected Secrets that carry a kubeconfig and calls fn for each,
+// with bounded parallelism. It returns the Secrets that were visited so callers can
+// drop state for clusters that disappeared.
+func forEachCluster(
+	ctx context.Context,
+	c client.Client,
+	opts Options,
+	matches func(client.Object) bool,
+	fn func(ctx context.Context, sec *corev1.Secret),
+) (map[types.NamespacedName]struct{}, error) {
+	// matches applies the selector; synthesized Secrets do not necessarily carry its labels
+	var list corev1.SecretList
+	if err := c.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("list secrets: %w", err)
+	}
+
+	seen := map[types.NamespacedName]struct{}{}
+	sem := make(chan struct{}, remoteWorkers)
+	var wg sync.WaitGroup
+	for i := range list.Items {
+		sec := &list.Items[i]
+		if !matches(sec) {
+			continue
+		}
+		if _, ok := sec.Data[kubeconfigKey(sec, opts)]; !ok {
+			continue
+		}
+		seen[client.ObjectKeyFromObject(sec)] = struct{}{}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sec *corev1.Secret) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(ctx, sec)
+		}(sec)
+	}
+	wg.Wait()
+	return seen, nil
+}
+
+// runEvery calls fn immediately and then on every tick until ctx is done.
+func runEvery(ctx context.Context, interval time.Duration, fn func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		fn(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
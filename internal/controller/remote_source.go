@@ -0,0 +1,162 @@
+// internal/controller/remote_source.go
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	generatedByRemote = "remote"
+	// sourceClusterLabel names the remote management cluster a kubeconfig Secret was copied from.
+	sourceClusterLabel = "mirror.fluxcd.io/sourceCluster"
+)
+
+// newRemoteCluster builds a controller-runtime cluster for a remote management cluster's kubeconfig file.
+func newRemoteCluster(kubeconfigPath string, opts func(*cluster.Options)) (cluster.Cluster, error) {
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("load kubeconfig %s: %w", kubeconfigPath, err)
+	}
+	return cluster.New(cfg, opts)
+}
+
+// RemoteSecretReconciler copies the selected kubeconfig Secrets of one remote management
+// cluster into the hub; the RSIP controller then picks the copies up like any other source.
+type RemoteSecretReconciler struct {
+	client.Client // hub
+	APIReader     client.Reader
+	Recorder      record.EventRecorder
+	Opts          Options
+
+	SourceCluster string
+	Remote        client.Reader // remote cache
+}
+
+// remoteSelected reports whether a Secret in a remote management cluster should be copied.
+func remoteSelected(sec client.Object, opts Options) bool {
+	if len(opts.WatchNamespaces) > 0 && !toStringSet(opts.WatchNamespaces).Has(sec.GetNamespace()) {
+		return false
+	}
+	return opts.LabelSelector.Matches(labels.Set(sec.GetLabels())) && !isGenerated(sec)
+}
+
+func (r *RemoteSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("remote").WithValues("sourceCluster", r.SourceCluster, "secret", req.NamespacedName.String())
+
+	var src corev1.Secret
+	if err := r.Remote.Get(ctx, req.NamespacedName, &src); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, r.deleteCopy(ctx, log, req.NamespacedName, "")
+	}
+	if !remoteSelected(&src, r.Opts) || !src.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, r.deleteCopy(ctx, log, req.NamespacedName, "")
+	}
+
+	// keep the remote labels (naming, project, label-copy), never our own bookkeeping
+	lbls := map[string]string{}
+	for k, v := range src.Labels {
+		if !strings.HasPrefix(k, "mirror.fluxcd.io/") {
+			lbls[k] = v
+		}
+	}
+	lbls[generatedByLabel] = generatedByRemote
+	lbls[sourceClusterLabel] = r.SourceCluster
+	lbls["mirror.fluxcd.io/remoteSecretNS"] = src.Namespace
+	lbls["mirror.fluxcd.io/remoteSecretName"] = src.Name
+
+	desired := &corev1.Secret{}
+	desired.SetNamespace(r.Opts.RemoteTargetNamespace)
+	desired.SetName(remoteCopyName(r.SourceCluster, src.Namespace, src.Name))
+	desired.SetLabels(lbls)
+	desired.SetAnnotations(src.Annotations)
+	desired.Type = corev1.SecretTypeOpaque
+	desired.Data = src.Data
+
+	var existing corev1.Secret
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	if client.IgnoreNotFound(err) != nil {
+		return reconcile.Result{}, err
+	}
+	if err != nil {
+		if err := r.Create(ctx, desired); err != nil {
+			log.Error(err, "create kubeconfig secret copy failed", "name", desired.Name)
+			return reconcile.Result{}, err
+		}
+		log.Info("created kubeconfig secret copy", "name", desired.Name, "ns", desired.Namespace)
+		// a copy written under an earlier naming scheme
+		return reconcile.Result{}, r.deleteCopy(ctx, log, req.NamespacedName, desired.Name)
+	}
+
+	if maps.Equal(existing.Labels, desired.Labels) &&
+		maps.Equal(existing.Annotations, desired.Annotations) &&
+		maps.EqualFunc(existing.Data, desired.Data, bytes.Equal) {
+		log.V(1).Info("kubeconfig secret copy up-to-date", "name", desired.Name)
+		return reconcile.Result{}, nil
+	}
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Data = desired.Data
+	if err := r.Update(ctx, &existing); err != nil {
+		r.Recorder.Eventf(&existing, corev1.EventTypeWarning, "KubeconfigSecretUpdateFailed",
+			"failed to update copy of %s/%s from %s: %v", src.Namespace, src.Name, r.SourceCluster, err)
+		return reconcile.Result{}, err
+	}
+	r.Recorder.Eventf(&existing, corev1.EventTypeNormal, "KubeconfigSecretUpdated",
+		"updated copy of %s/%s from %s", src.Namespace, src.Name, r.SourceCluster)
+	log.Info("updated kubeconfig secret copy", "name", existing.Name)
+	return reconcile.Result{}, nil
+}
+
+// deleteCopy removes the hub copies of a remote Secret other than keep ("" removes all).
+// Deleting one lets the RSIP controller clean up its RSIP as usual.
+func (r *RemoteSecretReconciler) deleteCopy(ctx context.Context, log logr.Logger, src types.NamespacedName, keep string) error {
+	var list corev1.SecretList
+	if err := r.APIReader.List(ctx, &list,
+		client.InNamespace(r.Opts.RemoteTargetNamespace),
+		client.MatchingLabels{
+			generatedByLabel:                    generatedByRemote,
+			sourceClusterLabel:                  r.SourceCluster,
+			"mirror.fluxcd.io/remoteSecretNS":   src.Namespace,
+			"mirror.fluxcd.io/remoteSecretName": src.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("list secret copies: %w", err)
+	}
+	for i := range list.Items {
+		if list.Items[i].Name == keep {
+			continue
+		}
+		if err := r.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted kubeconfig secret copy", "name", list.Items[i].Name)
+	}
+	return nil
+}
+
+// remoteCopyName is the hub name for a Secret copied from a remote management cluster. The
+// source cluster and namespace are DNS labels, so the dots keep every combination distinct.
+func remoteCopyName(sourceCluster, namespace, name string) string {
+	out := sourceCluster + "." + namespace + "." + name
+	if len(out) > 253 {
+		out = out[:253]
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+// internal/controller/resourceset_sink.go
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var resourceSetGVK = schema.GroupVersionKind{
+	Group:   "fluxcd.controlplane.io",
+	Version: "v1",
+	Kind:    "ResourceSet",
+}
+
+const (
+	// inputsSelectorAnnotation opts a ResourceSet in: its spec.inputs are kept in sync with the
+	// defaultValues of the RSIPs in its namespace matching this label selector.
+	inputsSelectorAnnotation = "fcg.io/inputs-selector"
+	// inputsFieldManager owns spec.inputs of the opted-in ResourceSets.
+	inputsFieldManager = "flux-cluster-generator"
+)
+
+// ResourceSetInputsReconciler maintains spec.inputs of opted-in ResourceSets with one input per
+// matching cluster, for ResourceSets that render a single object over the fleet. Only
+// spec.inputs is owned (server-side apply); removing the annotation releases and removes it.
+type ResourceSetInputsReconciler struct {
+	client.Client
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+func (r *ResourceSetInputsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
+	log := ctrl.Log.WithName("resourceset").WithValues("resourceSet", req.NamespacedName.String())
+
+	rs := &unstructured.Unstructured{}
+	rs.SetGroupVersionKind(resourceSetGVK)
+	if err := r.APIReader.Get(ctx, req.NamespacedName, rs); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+	if !rs.GetDeletionTimestamp().IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	apply := &unstructured.Unstructured{}
+	apply.SetGroupVersionKind(resourceSetGVK)
+	apply.SetNamespace(rs.GetNamespace())
+	apply.SetName(rs.GetName())
+
+	raw, optedIn := rs.GetAnnotations()[inputsSelectorAnnotation]
+	if !optedIn {
+		// an apply without spec.inputs drops the field if we still own it
+		if !ownsInputs(rs) {
+			return reconcile.Result{}, nil
+		}
+		if err := r.Patch(ctx, apply, client.Apply, client.FieldOwner(inputsFieldManager), client.ForceOwnership); err != nil {
+			return reconcile.Result{}, err
+		}
+		log.Info("released spec.inputs")
+		return reconcile.Result{}, nil
+	}
+
+	sel, err := labels.Parse(raw)
+	if err != nil {
+		r.Recorder.Eventf(rs, corev1.EventTypeWarning, "InvalidInputsSelector",
+			"invalid %s %q: %v", inputsSelectorAnnotation, raw, err)
+		return reconcile.Result{}, nil
+	}
+	inputs, err := r.inputsFor(ctx, rs.GetNamespace(), sel)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	current, _, _ := unstructured.NestedSlice(rs.Object, "spec", "inputs")
+	if ownsInputs(rs) && equality.Semantic.DeepEqual(current, inputs) {
+		log.V(1).Info("spec.inputs up-to-date", "clusters", len(inputs))
+		return reconcile.Result{}, nil
+	}
+	if err := unstructured.SetNestedSlice(apply.Object, inputs, "spec", "inputs"); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.Patch(ctx, apply, client.Apply, client.FieldOwner(inputsFieldManager), client.ForceOwnership); err != nil {
+		r.Recorder.Eventf(rs, corev1.EventTypeWarning, "InputsUpdateFailed", "failed to apply spec.inputs: %v", err)
+		return reconcile.Result{}, err
+	}
+	r.Recorder.Eventf(rs, corev1.EventTypeNormal, "InputsUpdated", "spec.inputs set to %d cluster(s)", len(inputs))
+	log.Info("updated spec.inputs", "clusters", len(inputs))
+	return reconcile.Result{}, nil
+}
+
+// inputsFor returns the defaultValues of the managed RSIPs in namespace matching sel, ordered
+// by RSIP name. Only the ResourceSet's own namespace is searched, as with inputsFrom.
+func (r *ResourceSetInputsReconciler) inputsFor(ctx context.Context, namespace string, sel labels.Selector) ([]any, error) {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := r.APIReader.List(ctx, &list,
+		client.InNamespace(namespace),
+		client.MatchingLabels{"mirror.fluxcd.io/managed": "true"},
+	); err != nil {
+		return nil, fmt.Errorf("list RSIPs: %w", err)
+	}
+	items := slices.DeleteFunc(list.Items, func(u unstructured.Unstructured) bool {
+		return !sel.Matches(labels.Set(u.GetLabels()))
+	})
+	slices.SortFunc(items, func(a, b unstructured.Unstructured) int { return strings.Compare(a.GetName(), b.GetName()) })
+
+	inputs := make([]any, 0, len(items))
+	for i := range items {
+		dv, found, err := unstructured.NestedMap(items[i].Object, "spec", "defaultValues")
+		if err != nil || !found {
+			continue
+		}
+		inputs = append(inputs, dv)
+	}
+	return inputs, nil
+}
+
+// ownsInputs reports whether the controller's field manager owns fields under spec.inputs.
+func ownsInputs(rs *unstructured.Unstructured) bool {
+	for _, mf := range rs.GetManagedFields() {
+		if mf.Manager == inputsFieldManager && mf.FieldsV1 != nil &&
+			strings.Contains(string(mf.FieldsV1.Raw), `"f:inputs"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceSetInputsPredicate passes ResourceSets that are or were opted in.
+func resourceSetInputsPredicate() predicate.Funcs {
+	optedIn := func(o client.Object) bool {
+		_, ok := o.GetAnnotations()[inputsSelectorAnnotation]
+		return ok
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return optedIn(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if !optedIn(e.ObjectOld) && !optedIn(e.ObjectNew) {
+				return false
+			}
+			// not on status updates: only the annotation or the spec (someone else editing inputs)
+			return e.ObjectOld.GetAnnotations()[inputsSelectorAnnotation] != e.ObjectNew.GetAnnotations()[inputsSelectorAnnotation] ||
+				optedIn(e.ObjectOld) != optedIn(e.ObjectNew) ||
+				e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return optedIn(e.Object) },
+	}
+}
+
+// rsipToResourceSets re-reconciles the opted-in ResourceSets in an RSIP's namespace.
+func rsipToResourceSets(c client.Reader) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list unstructured.UnstructuredList
+		list.SetGroupVersionKind(schema.GroupVersionKind{
+			Group: resourceSetGVK.Group, Version: resourceSetGVK.Version, Kind: resourceSetGVK.Kind + "List",
+		})
+		if err := c.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+			ctrl.Log.WithName("resourceset").Error(err, "list ResourceSets for RSIP change failed",
+				"rsip", client.ObjectKeyFromObject(obj).String())
+			return nil
+		}
+		var reqs []reconcile.Request
+		for i := range list.Items {
+			if _, ok := list.Items[i].GetAnnotations()[inputsSelectorAnnotation]; ok {
+				reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+			}
+		}
+		return reqs
+	}
+}
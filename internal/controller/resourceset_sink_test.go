@@ -0,0 +1,61 @@
+// internal/controller/resourceset_sink_test.go
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestOwnsInputs(t *testing.T) {
+	entry := func(manager, fields string) metav1.ManagedFieldsEntry {
+		mf := metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationApply}
+		if fields != "" {
+			mf.FieldsV1 = &metav1.FieldsV1{Raw: []byte(fields)}
+		}
+		return mf
+	}
+	tests := []struct {
+		name   string
+		fields []metav1.ManagedFieldsEntry
+		want   bool
+	}{
+		{name: "no managed fields"},
+		{
+			name:   "owns spec.inputs",
+			fields: []metav1.ManagedFieldsEntry{entry(inputsFieldManager, `{"f:spec":{"f:inputs":{}}}`)},
+			want:   true,
+		},
+		{
+			name:   "other manager owns spec.inputs",
+			fields: []metav1.ManagedFieldsEntry{entry("kustomize-controller", `{"f:spec":{"f:inputs":{}}}`)},
+		},
+		{
+			name:   "own entry without inputs (released)",
+			fields: []metav1.ManagedFieldsEntry{entry(inputsFieldManager, `{"f:metadata":{"f:annotations":{}}}`)},
+		},
+		{
+			name:   "own entry without fields",
+			fields: []metav1.ManagedFieldsEntry{entry(inputsFieldManager, "")},
+		},
+		{
+			name: "owns spec.inputs beside another manager",
+			fields: []metav1.ManagedFieldsEntry{
+				entry("kustomize-controller", `{"f:spec":{"f:resources":{}}}`),
+				entry(inputsFieldManager, `{"f:spec":{"f:inputs":{}}}`),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rs unstructured.Unstructured
+			rs.SetGroupVersionKind(resourceSetGVK)
+			rs.SetManagedFields(tt.fields)
+			if got := ownsInputs(&rs); got != tt.want {
+				t.Errorf("ownsInputs = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
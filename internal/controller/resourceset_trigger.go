@@ -0,0 +1,180 @@
+// internal/controller/resourceset_trigger.go
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// requestedAtAnnotation asks the Flux Operator to reconcile a ResourceSet now.
+const requestedAtAnnotation = "reconcile.fluxcd.io/requestedAt"
+
+// changedRSIP is an RSIP that was created, updated or deleted since the last flush.
+type changedRSIP struct {
+	name   string
+	labels map[string]string
+}
+
+// resourceSetTrigger annotates the ResourceSets whose inputsFrom select a changed RSIP with
+// reconcile.fluxcd.io/requestedAt, so the Flux Operator picks changes up without waiting for
+// its interval. Changes are batched per --resourceset-trigger-interval-seconds, and each
+// ResourceSet is requested at most once per batch.
+type resourceSetTrigger struct {
+	Cache     cache.Cache
+	Client    client.Client
+	APIReader client.Reader
+	Interval  time.Duration
+
+	mu      sync.Mutex
+	pending map[string][]changedRSIP // namespace -> changed RSIPs
+}
+
+// Start implements manager.Runnable.
+func (t *resourceSetTrigger) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("resourceset-trigger")
+	t.pending = map[string][]changedRSIP{}
+
+	rsip := &unstructured.Unstructured{}
+	rsip.SetGroupVersionKind(rsipGVK)
+	inf, err := t.Cache.GetInformer(ctx, rsip)
+	if err != nil {
+		return fmt.Errorf("get RSIP informer: %w", err)
+	}
+	if _, err := inf.AddEventHandler(toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			if !isInInitialList { // the initial list is not a change
+				t.record(obj)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			o, okOld := oldObj.(client.Object)
+			n, okNew := newObj.(client.Object)
+			if okOld && okNew && o.GetGeneration() == n.GetGeneration() && maps.Equal(o.GetLabels(), n.GetLabels()) {
+				return // status or annotations only (e.g. rollout status)
+			}
+			// the old labels may be the ones a ResourceSet selected on
+			t.record(oldObj)
+			t.record(newObj)
+		},
+		DeleteFunc: t.record,
+	}); err != nil {
+		return fmt.Errorf("add RSIP event handler: %w", err)
+	}
+
+	runEvery(ctx, t.Interval, func(ctx context.Context) {
+		if err := t.flush(ctx, log); err != nil {
+			log.Error(err, "resourceset trigger failed")
+		}
+	})
+	return nil
+}
+
+func (t *resourceSetTrigger) record(obj any) {
+	if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	o, ok := obj.(client.Object)
+	if !ok || o.GetLabels()["mirror.fluxcd.io/managed"] != "true" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending[o.GetNamespace()] = append(t.pending[o.GetNamespace()],
+		changedRSIP{name: o.GetName(), labels: maps.Clone(o.GetLabels())})
+}
+
+// flush requests a reconciliation of every ResourceSet selecting an RSIP changed since the last flush.
+func (t *resourceSetTrigger) flush(ctx context.Context, log logr.Logger) error {
+	t.mu.Lock()
+	pending := t.pending
+	t.pending = map[string][]changedRSIP{}
+	t.mu.Unlock()
+
+	requested := 0
+	var errs []error
+	for ns, changed := range pending {
+		var list unstructured.UnstructuredList
+		list.SetGroupVersionKind(schema.GroupVersionKind{
+			Group: resourceSetGVK.Group, Version: resourceSetGVK.Version, Kind: resourceSetGVK.Kind + "List",
+		})
+		if err := t.APIReader.List(ctx, &list, client.InNamespace(ns)); err != nil {
+			// retry this namespace with the next batch; the others go ahead
+			t.mu.Lock()
+			t.pending[ns] = append(t.pending[ns], changed...)
+			t.mu.Unlock()
+			errs = append(errs, fmt.Errorf("list ResourceSets in %s: %w", ns, err))
+			continue
+		}
+		for i := range list.Items {
+			rs := &list.Items[i]
+			if !rs.GetDeletionTimestamp().IsZero() || !selectsAny(rs, changed) {
+				continue
+			}
+			patch := client.MergeFrom(rs.DeepCopy())
+			ann := rs.GetAnnotations()
+			if ann == nil {
+				ann = map[string]string{}
+			}
+			ann[requestedAtAnnotation] = time.Now().Format(time.RFC3339Nano)
+			rs.SetAnnotations(ann)
+			if err := t.Client.Patch(ctx, rs, patch); client.IgnoreNotFound(err) != nil {
+				log.Error(err, "request ResourceSet reconciliation failed", "resourceSet", client.ObjectKeyFromObject(rs).String())
+				continue
+			}
+			requested++
+			log.V(1).Info("requested ResourceSet reconciliation", "resourceSet", client.ObjectKeyFromObject(rs).String())
+		}
+	}
+	if requested > 0 {
+		log.Info("requested ResourceSet reconciliations", "count", requested)
+	}
+	return errors.Join(errs...)
+}
+
+// selectsAny reports whether one of the ResourceSet's inputsFrom entries selects a changed RSIP,
+// by name or by label selector.
+func selectsAny(rs *unstructured.Unstructured, changed []changedRSIP) bool {
+	refs, _, _ := unstructured.NestedSlice(rs.Object, "spec", "inputsFrom")
+	for _, raw := range refs {
+		ref, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if kind, _ := ref["kind"].(string); kind != "" && kind != rsipGVK.Kind {
+			continue
+		}
+		name, _ := ref["name"].(string)
+		var sel labels.Selector
+		if s, ok := ref["selector"].(map[string]any); ok {
+			var ls metav1.LabelSelector
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(s, &ls); err != nil {
+				continue
+			}
+			var err error
+			if sel, err = metav1.LabelSelectorAsSelector(&ls); err != nil {
+				continue
+			}
+		}
+		for _, c := range changed {
+			if (name != "" && name == c.name) || (name == "" && sel != nil && sel.Matches(labels.Set(c.labels))) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// internal/controller/resourceset_trigger_test.go
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSelectsAny(t *testing.T) {
+	changed := []changedRSIP{
+		{name: "inputs-team-a-dev-1", labels: map[string]string{"env": "dev", "tier": "edge"}},
+		{name: "inputs-team-b-prod-1", labels: map[string]string{"env": "prod"}},
+	}
+	tests := []struct {
+		name       string
+		inputsFrom []any
+		want       bool
+	}{
+		{name: "no inputsFrom"},
+		{
+			name:       "by name",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind, "name": "inputs-team-b-prod-1"}},
+			want:       true,
+		},
+		{
+			name:       "by name, kind omitted",
+			inputsFrom: []any{map[string]any{"name": "inputs-team-a-dev-1"}},
+			want:       true,
+		},
+		{
+			name:       "other name",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind, "name": "inputs-team-c-dev-1"}},
+		},
+		{
+			name: "by matchLabels",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind, "selector": map[string]any{
+				"matchLabels": map[string]any{"tier": "edge"},
+			}}},
+			want: true,
+		},
+		{
+			name: "by matchExpressions",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind, "selector": map[string]any{
+				"matchExpressions": []any{map[string]any{"key": "env", "operator": "In", "values": []any{"prod", "staging"}}},
+			}}},
+			want: true,
+		},
+		{
+			name: "selector matching nothing",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind, "selector": map[string]any{
+				"matchLabels": map[string]any{"env": "staging"},
+			}}},
+		},
+		{
+			name: "other kind",
+			inputsFrom: []any{map[string]any{"kind": "ConfigMap", "selector": map[string]any{
+				"matchLabels": map[string]any{"env": "dev"},
+			}}},
+		},
+		{
+			name: "invalid selector is skipped, later entry matches",
+			inputsFrom: []any{
+				map[string]any{"kind": rsipGVK.Kind, "selector": map[string]any{
+					"matchExpressions": []any{map[string]any{"key": "env", "operator": "Bogus"}},
+				}},
+				map[string]any{"kind": rsipGVK.Kind, "name": "inputs-team-a-dev-1"},
+			},
+			want: true,
+		},
+		{
+			name:       "neither name nor selector",
+			inputsFrom: []any{map[string]any{"kind": rsipGVK.Kind}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rs unstructured.Unstructured
+			rs.SetGroupVersionKind(resourceSetGVK)
+			if tt.inputsFrom != nil {
+				if err := unstructured.SetNestedSlice(rs.Object, tt.inputsFrom, "spec", "inputsFrom"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := selectsAny(&rs, changed); got != tt.want {
+				t.Errorf("selectsAny = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
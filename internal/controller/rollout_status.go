@@ -0,0 +1,281 @@
+// internal/controller/rollout_status.go
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	helmReleaseGVK   = schema.GroupVersionKind{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"}
+	kustomizationGVK = schema.GroupVersionKind{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"}
+)
+
+const (
+	// rolloutClusterLabel on a HelmRelease/Kustomization names the cluster it deploys to.
+	rolloutClusterLabel = "mirror.fluxcd.io/clusterName"
+	// rolloutAnnotation and rolloutReadyAnnotation publish a cluster's rollout on its RSIPs.
+	rolloutAnnotation      = "status.fcg.io/rollout"
+	rolloutReadyAnnotation = "status.fcg.io/rollout-ready"
+	// rolloutStatusKey is the summary ConfigMap key.
+	rolloutStatusKey = "clusters.json"
+	// rolloutFieldManager owns the rollout annotations, apart from the RSIP writes (rsipFieldManager).
+	rolloutFieldManager = "flux-cluster-generator-rollout"
+	// rolloutInterval bounds how often the status is recomputed after changes.
+	rolloutInterval = 10 * time.Second
+
+	rolloutReady       = "Ready"
+	rolloutProgressing = "Progressing"
+	rolloutFailed      = "Failed"
+)
+
+var (
+	rolloutResources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fcg_cluster_rollout_resources",
+		Help: "HelmReleases and Kustomizations deploying to a cluster, by Ready state (ready, failed, progressing).",
+	}, []string{"cluster", "state"})
+	rolloutClusterReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "fcg_cluster_rollout_ready",
+		Help: "1 if every HelmRelease and Kustomization deploying to the cluster is Ready.",
+	}, []string{"cluster"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(rolloutResources, rolloutClusterReady)
+}
+
+// clusterRollout is the rolled-up Ready state of the Flux objects deploying to one cluster.
+type clusterRollout struct {
+	Cluster     string   `json:"cluster"`
+	Status      string   `json:"status"`
+	Ready       int      `json:"ready"`
+	Failed      int      `json:"failed"`
+	Progressing int      `json:"progressing"`
+	NotReady    []string `json:"notReady,omitempty"` // Kind/namespace/name, sorted
+}
+
+// rolloutAggregator rolls the Ready conditions of HelmReleases and Kustomizations labeled
+// mirror.fluxcd.io/clusterName up per cluster and publishes them as RSIP annotations,
+// metrics and a summary ConfigMap.
+type rolloutAggregator struct {
+	Cache     cache.Cache
+	Client    client.Client
+	APIReader client.Reader
+	Opts      Options
+
+	dirty atomic.Bool
+}
+
+// Start implements manager.Runnable.
+func (a *rolloutAggregator) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("rollout")
+	for _, gvk := range []schema.GroupVersionKind{helmReleaseGVK, kustomizationGVK} {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		inf, err := a.Cache.GetInformer(ctx, u)
+		if err != nil {
+			return fmt.Errorf("get %s informer: %w", gvk.Kind, err)
+		}
+		mark := func(any) { a.dirty.Store(true) }
+		if _, err := inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+			AddFunc:    mark,
+			UpdateFunc: func(_, obj any) { mark(obj) },
+			DeleteFunc: mark,
+		}); err != nil {
+			return fmt.Errorf("add %s event handler: %w", gvk.Kind, err)
+		}
+	}
+
+	a.dirty.Store(true)
+	runEvery(ctx, rolloutInterval, func(ctx context.Context) {
+		if !a.dirty.Swap(false) {
+			return
+		}
+		if err := a.publish(ctx, log); err != nil {
+			a.dirty.Store(true) // retry on the next tick
+			log.Error(err, "rollout status publish failed")
+		}
+	})
+	return nil
+}
+
+// aggregate rolls up the cached HelmReleases and Kustomizations per cluster.
+func (a *rolloutAggregator) aggregate(ctx context.Context) (map[string]*clusterRollout, error) {
+	out := map[string]*clusterRollout{}
+	for _, gvk := range []schema.GroupVersionKind{helmReleaseGVK, kustomizationGVK} {
+		var list unstructured.UnstructuredList
+		list.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
+		if err := a.Cache.List(ctx, &list, client.HasLabels{rolloutClusterLabel}); err != nil {
+			return nil, fmt.Errorf("list %s: %w", gvk.Kind, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			cluster := obj.GetLabels()[rolloutClusterLabel]
+			cr := out[cluster]
+			if cr == nil {
+				cr = &clusterRollout{Cluster: cluster}
+				out[cluster] = cr
+			}
+			// a Ready condition from before the last spec change is not the rollout's outcome
+			observed, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+			ready := conditionStatus(obj, "Ready")
+			if found && observed < obj.GetGeneration() {
+				ready = ""
+			}
+			switch ready {
+			case "True":
+				cr.Ready++
+				continue
+			case "False":
+				cr.Failed++
+			default:
+				cr.Progressing++
+			}
+			cr.NotReady = append(cr.NotReady, gvk.Kind+"/"+obj.GetNamespace()+"/"+obj.GetName())
+		}
+	}
+	for _, cr := range out {
+		slices.Sort(cr.NotReady)
+		switch {
+		case cr.Failed > 0:
+			cr.Status = rolloutFailed
+		case cr.Progressing > 0:
+			cr.Status = rolloutProgressing
+		default:
+			cr.Status = rolloutReady
+		}
+	}
+	return out, nil
+}
+
+func (a *rolloutAggregator) publish(ctx context.Context, log logr.Logger) error {
+	rollouts, err := a.aggregate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rolloutResources.Reset()
+	rolloutClusterReady.Reset()
+	for name, cr := range rollouts {
+		rolloutResources.WithLabelValues(name, "ready").Set(float64(cr.Ready))
+		rolloutResources.WithLabelValues(name, "failed").Set(float64(cr.Failed))
+		rolloutResources.WithLabelValues(name, "progressing").Set(float64(cr.Progressing))
+		ready := 0.0
+		if cr.Status == rolloutReady {
+			ready = 1
+		}
+		rolloutClusterReady.WithLabelValues(name).Set(ready)
+	}
+
+	return errors.Join(a.annotateRSIPs(ctx, log, rollouts), a.writeSummary(ctx, log, rollouts))
+}
+
+// annotateRSIPs sets (or clears, for clusters without Flux objects) the rollout annotations
+// on every managed RSIP.
+func (a *rolloutAggregator) annotateRSIPs(ctx context.Context, log logr.Logger, rollouts map[string]*clusterRollout) error {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := a.APIReader.List(ctx, &list,
+		managedRSIPs(labels.Everything()),
+	); err != nil {
+		return fmt.Errorf("list RSIPs: %w", err)
+	}
+	for i := range list.Items {
+		rsip := &list.Items[i]
+		ann := maps.Clone(rsip.GetAnnotations())
+		if ann == nil {
+			ann = map[string]string{}
+		}
+		if cr, ok := rollouts[rsip.GetLabels()["mirror.fluxcd.io/clusterName"]]; ok {
+			ann[rolloutAnnotation] = cr.Status
+			ann[rolloutReadyAnnotation] = fmt.Sprintf("%d/%d", cr.Ready, cr.Ready+cr.Failed+cr.Progressing)
+		} else {
+			delete(ann, rolloutAnnotation)
+			delete(ann, rolloutReadyAnnotation)
+		}
+		if maps.Equal(ann, rsip.GetAnnotations()) || (len(ann) == 0 && len(rsip.GetAnnotations()) == 0) {
+			continue
+		}
+		patch := client.MergeFrom(rsip.DeepCopy())
+		rsip.SetAnnotations(ann)
+		if err := a.Client.Patch(ctx, rsip, patch, client.FieldOwner(rolloutFieldManager)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("annotate RSIP %s/%s: %w", rsip.GetNamespace(), rsip.GetName(), err)
+		}
+		log.V(1).Info("updated RSIP rollout status", "name", rsip.GetName(), "ns", rsip.GetNamespace(),
+			"status", ann[rolloutAnnotation])
+	}
+	return nil
+}
+
+// writeSummary keeps the --rollout-status-configmap summary in --rsip-namespace up to date.
+func (a *rolloutAggregator) writeSummary(ctx context.Context, log logr.Logger, rollouts map[string]*clusterRollout) error {
+	if a.Opts.RolloutStatusConfigMap == "" {
+		return nil
+	}
+	ordered := make([]*clusterRollout, 0, len(rollouts))
+	for _, name := range slices.Sorted(maps.Keys(rollouts)) {
+		ordered = append(ordered, rollouts[name])
+	}
+	raw, err := json.MarshalIndent(ordered, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	desired := &corev1.ConfigMap{}
+	desired.SetNamespace(a.Opts.RSIPNamespace)
+	desired.SetName(a.Opts.RolloutStatusConfigMap)
+	desired.SetLabels(map[string]string{"mirror.fluxcd.io/managed": "true"})
+	desired.Data = map[string]string{rolloutStatusKey: string(raw)}
+
+	var existing corev1.ConfigMap
+	err = a.APIReader.Get(ctx, client.ObjectKeyFromObject(desired), &existing)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return err
+	case err != nil:
+		if err := a.Client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("create rollout status ConfigMap: %w", err)
+		}
+		log.Info("created rollout status ConfigMap", "name", desired.Name, "clusters", len(ordered))
+	case existing.Data[rolloutStatusKey] != desired.Data[rolloutStatusKey]:
+		existing.Data = desired.Data
+		if err := a.Client.Update(ctx, &existing); err != nil {
+			return fmt.Errorf("update rollout status ConfigMap: %w", err)
+		}
+		log.V(1).Info("updated rollout status ConfigMap", "name", existing.Name, "clusters", len(ordered))
+	}
+	return nil
+}
+
+// conditionStatus returns the status ("True", "False", "Unknown") of a condition, or "" if absent.
+func conditionStatus(u *unstructured.Unstructured, condType string) string {
+	conds, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conds {
+		m, ok := c.(map[string]any)
+		if ok && m["type"] == condType {
+			return strings.TrimSpace(toString(m["status"]))
+		}
+	}
+	return ""
+}
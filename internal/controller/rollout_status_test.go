@@ -0,0 +1,132 @@
+// internal/controller/rollout_status_test.go
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listCache is a cache.Cache that only lists the given objects of the requested kind that
+// carry the rollout cluster label.
+type listCache struct {
+	cache.Cache
+	objs []*unstructured.Unstructured
+}
+
+func (c listCache) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	ul := list.(*unstructured.UnstructuredList)
+	kind := strings.TrimSuffix(ul.GetKind(), "List")
+	for _, o := range c.objs {
+		if o.GetKind() == kind && o.GetLabels()[rolloutClusterLabel] != "" {
+			ul.Items = append(ul.Items, *o.DeepCopy())
+		}
+	}
+	return nil
+}
+
+// deployment returns a HelmRelease or Kustomization deploying to cluster with the given Ready
+// status ("" for none) and observed generation (0 for none).
+func deployment(gvk schema.GroupVersionKind, name, cluster, ready string, generation, observed int64) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetNamespace("apps")
+	u.SetName(name)
+	u.SetGeneration(generation)
+	if cluster != "" {
+		u.SetLabels(map[string]string{rolloutClusterLabel: cluster})
+	}
+	if ready != "" {
+		_ = unstructured.SetNestedSlice(u.Object, []any{map[string]any{"type": "Ready", "status": ready}},
+			"status", "conditions")
+	}
+	if observed > 0 {
+		_ = unstructured.SetNestedField(u.Object, observed, "status", "observedGeneration")
+	}
+	return u
+}
+
+func TestRolloutAggregate(t *testing.T) {
+	tests := []struct {
+		name string
+		objs []*unstructured.Unstructured
+		want map[string]*clusterRollout
+	}{
+		{name: "nothing deployed", want: map[string]*clusterRollout{}},
+		{
+			name: "all ready",
+			objs: []*unstructured.Unstructured{
+				deployment(helmReleaseGVK, "podinfo", "dev-1", "True", 2, 2),
+				deployment(kustomizationGVK, "infra", "dev-1", "True", 1, 1),
+			},
+			want: map[string]*clusterRollout{
+				"dev-1": {Cluster: "dev-1", Status: rolloutReady, Ready: 2},
+			},
+		},
+		{
+			name: "failed wins over progressing",
+			objs: []*unstructured.Unstructured{
+				deployment(helmReleaseGVK, "podinfo", "dev-1", "False", 1, 1),
+				deployment(helmReleaseGVK, "redis", "dev-1", "Unknown", 1, 1),
+				deployment(kustomizationGVK, "infra", "dev-1", "True", 1, 1),
+			},
+			want: map[string]*clusterRollout{
+				"dev-1": {Cluster: "dev-1", Status: rolloutFailed, Ready: 1, Failed: 1, Progressing: 1,
+					NotReady: []string{"HelmRelease/apps/podinfo", "HelmRelease/apps/redis"}},
+			},
+		},
+		{
+			name: "Ready from an older generation is progressing",
+			objs: []*unstructured.Unstructured{
+				deployment(helmReleaseGVK, "podinfo", "dev-1", "True", 3, 2),
+				deployment(helmReleaseGVK, "failing", "dev-2", "False", 5, 4),
+			},
+			want: map[string]*clusterRollout{
+				"dev-1": {Cluster: "dev-1", Status: rolloutProgressing, Progressing: 1,
+					NotReady: []string{"HelmRelease/apps/podinfo"}},
+				"dev-2": {Cluster: "dev-2", Status: rolloutProgressing, Progressing: 1,
+					NotReady: []string{"HelmRelease/apps/failing"}},
+			},
+		},
+		{
+			name: "no condition or observedGeneration yet",
+			objs: []*unstructured.Unstructured{
+				deployment(kustomizationGVK, "apps", "dev-1", "", 1, 0),
+				deployment(kustomizationGVK, "base", "dev-1", "True", 1, 0),
+			},
+			want: map[string]*clusterRollout{
+				"dev-1": {Cluster: "dev-1", Status: rolloutProgressing, Ready: 1, Progressing: 1,
+					NotReady: []string{"Kustomization/apps/apps"}},
+			},
+		},
+		{
+			name: "not labeled with a cluster",
+			objs: []*unstructured.Unstructured{deployment(helmReleaseGVK, "podinfo", "", "False", 1, 1)},
+			want: map[string]*clusterRollout{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &rolloutAggregator{Cache: listCache{objs: tt.objs}}
+			got, err := a.aggregate(context.Background())
+			if err != nil {
+				t.Fatalf("aggregate: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("aggregate =")
+				for k, v := range got {
+					t.Errorf("  got %s: %+v", k, *v)
+				}
+				for k, v := range tt.want {
+					t.Errorf("  want %s: %+v", k, *v)
+				}
+			}
+		})
+	}
+}
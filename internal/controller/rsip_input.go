@@ -0,0 +1,217 @@
+// internal/controller/rsip_input.go
+package controller
+
+import (
+	"bytes"
+	"maps"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rsipInput is the source-independent description of one cluster an RSIP is generated for.
+type rsipInput struct {
+	Name          string            // source object name (template .name, cluster name fallback)
+	Namespace     string            // source object namespace (template .namespace)
+	Labels        map[string]string // labels used for naming and label-copy
+	Annotations   map[string]string
+	ClusterName   string            // cluster name when --cluster-name-label-key is absent (default: Name)
+	ProjectNS     string            // namespace the project is derived from when unlabeled (default: Namespace)
+	SourceCluster string            // remote management cluster the source was copied from, if any
+	RefLabels     map[string]string // mirror.fluxcd.io/* labels identifying the source object
+	RefValues     map[string]any    // defaultValues telling Flux how to reach the cluster
+}
+
+// rsipSkeleton computes the RSIP name and namespace, labels and defaultValues every source
+// shares: cluster/project, the naming templates, copied labels and the source reference.
+func (r *SecretMirrorReconciler) rsipSkeleton(log logr.Logger, in rsipInput) (rsip types.NamespacedName, clusterName string, lbls map[string]string, dv map[string]any) {
+	// --- derive cluster/project for defaultValues (legacy behavior) ---
+	clusterName = in.Labels[r.Opts.ClusterNameKey]
+	if clusterName == "" {
+		clusterName = in.ClusterName
+	}
+	if clusterName == "" {
+		clusterName = in.Name
+	}
+	if errs := validation.IsDNS1123Label(clusterName); len(errs) > 0 {
+		log.V(1).Info("sanitizing cluster name to DNS-1123", "errors", errs)
+		clusterName = sanitizeDNS1123(clusterName)
+	}
+	project := strings.TrimSpace(in.Labels[r.Opts.ProjectLabelKey])
+	if project == "" && in.ProjectNS != "" {
+		project = projectFromNamespace(in.ProjectNS)
+	}
+	if project == "" {
+		project = projectFromNamespace(in.Namespace)
+	}
+	project = sanitizeDNS1123(project)
+
+	// --- RSIP name: template (if provided) OR fallback to legacy "prefix+project-cluster" ---
+	var rsipName string
+	if r.Opts.RSIPNameTemplate != nil {
+		var buf bytes.Buffer
+		if err := r.Opts.RSIPNameTemplate.Execute(&buf, in.templateContext()); err != nil {
+			log.Error(err, "rsip-name-template execution failed; falling back to project/cluster")
+		} else if out := strings.TrimSpace(buf.String()); out != "" {
+			rsipName = r.Opts.RSIPNamePrefix + out
+		}
+	}
+	if rsipName == "" {
+		// legacy fallback
+		rsipName = r.Opts.RSIPNamePrefix
+		if project != "" {
+			rsipName += project + "-"
+		}
+		rsipName += clusterName
+	}
+	// the same project/cluster may exist on several remote management clusters
+	if in.SourceCluster != "" {
+		rsipName += "-" + sanitizeDNS1123(in.SourceCluster)
+	}
+	if len(rsipName) > 253 {
+		rsipName = rsipName[:253]
+	}
+	log.V(1).Info("computed RSIP name", "rsipName", rsipName, "templated", r.Opts.RSIPNameTemplate != nil)
+
+	lbls = map[string]string{
+		"mirror.fluxcd.io/managed":     "true",
+		"mirror.fluxcd.io/clusterName": clusterName,
+		"mirror.fluxcd.io/project":     project,
+	}
+	maps.Copy(lbls, in.RefLabels)
+	for _, k := range r.Opts.CopyLabelKeys {
+		if v, ok := in.Labels[k]; ok {
+			lbls[k] = v
+		}
+	}
+	for k, v := range in.Labels {
+		if hasAnyPrefix(r.Opts.CopyLabelPrefixes, k) {
+			lbls[k] = v
+		}
+	}
+
+	dv = map[string]any{
+		"name":    clusterName,
+		"project": project,
+	}
+	maps.Copy(dv, in.RefValues)
+	reserved := sets.KeySet(dv)
+	for _, k := range r.Opts.CopyLabelKeys {
+		if v, ok := in.Labels[k]; ok {
+			ck := toCamel(k)
+			if !reserved.Has(ck) {
+				dv[ck] = v
+			}
+		}
+	}
+	for k, v := range in.Labels {
+		if hasAnyPrefix(r.Opts.CopyLabelPrefixes, k) {
+			ck := toCamel(k)
+			if !reserved.Has(ck) {
+				dv[ck] = v
+			}
+		}
+	}
+	// CMDB attributes are matched by cluster name, whatever the source
+	if r.CMDB != nil {
+		r.CMDB.apply(clusterName, lbls, dv, reserved)
+	}
+	rsip = types.NamespacedName{Namespace: r.rsipNamespace(log, in), Name: rsipName}
+	return rsip, clusterName, lbls, dv
+}
+
+// templateContext is the context the naming templates are executed with.
+func (in rsipInput) templateContext() map[string]any {
+	return map[string]any{
+		"name":        in.Name,
+		"namespace":   in.Namespace,
+		"labels":      in.Labels,
+		"annotations": in.Annotations,
+	}
+}
+
+// rsipNamespace renders --rsip-namespace-template for a source, falling back to
+// --rsip-namespace when the template is unset, fails or yields an invalid namespace.
+func (r *SecretMirrorReconciler) rsipNamespace(log logr.Logger, in rsipInput) string {
+	if r.Opts.RSIPNamespaceTemplate == nil {
+		return r.Opts.RSIPNamespace
+	}
+	var buf bytes.Buffer
+	if err := r.Opts.RSIPNamespaceTemplate.Execute(&buf, in.templateContext()); err != nil {
+		log.Error(err, "rsip-namespace-template execution failed; using --rsip-namespace")
+		return r.Opts.RSIPNamespace
+	}
+	ns := strings.TrimSpace(buf.String())
+	if errs := validation.IsDNS1123Label(ns); len(errs) > 0 {
+		log.Info("rsip-namespace-template produced an invalid namespace; using --rsip-namespace",
+			"namespace", ns, "errors", errs)
+		return r.Opts.RSIPNamespace
+	}
+	return ns
+}
+
+// sourceRefKeys are the RSIP labels that identify the source object, one set per source kind.
+var sourceRefKeys = []string{
+	"mirror.fluxcd.io/secretNS", "mirror.fluxcd.io/secretName",
+	"mirror.fluxcd.io/configMapNS", "mirror.fluxcd.io/configMapName",
+	"mirror.fluxcd.io/clusterProfileNS", "mirror.fluxcd.io/clusterProfileName",
+	managedClusterLabel, inventoryEntryLabel, cmdbEntryLabel, localClusterLabel,
+}
+
+// sourceRef selects the RSIPs generated for the same source as an RSIP with lbls. The
+// reference keys it lacks must be absent too: an inventory RSIP also names its Secret.
+func sourceRef(lbls map[string]string) (labels.Selector, bool) {
+	sel := labels.NewSelector()
+	found := false
+	for _, k := range sourceRefKeys {
+		op, vals := selection.DoesNotExist, []string(nil)
+		if v, ok := lbls[k]; ok {
+			op, vals, found = selection.Equals, []string{v}, true
+		}
+		req, err := labels.NewRequirement(k, op, vals)
+		if err != nil {
+			return nil, false
+		}
+		sel = sel.Add(*req)
+	}
+	return sel, found
+}
+
+// sameSourceRef reports whether two RSIPs' labels reference the same source object.
+func sameSourceRef(a, b map[string]string) bool {
+	for _, k := range sourceRefKeys {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// managedRSIPs selects the RSIPs the controller wrote that also match sel. Lists using it are
+// never restricted to a namespace: --rsip-namespace, the namespace template or the fan-out
+// targets may have changed since an RSIP was written, and it must still be found.
+func managedRSIPs(sel labels.Selector) client.MatchingLabelsSelector {
+	managed, _ := labels.NewRequirement("mirror.fluxcd.io/managed", selection.Equals, []string{"true"})
+	return client.MatchingLabelsSelector{Selector: sel.Add(*managed)}
+}
+
+// newRSIP assembles a Static ResourceSetInputProvider.
+func newRSIP(namespace, name string, lbls map[string]string, dv map[string]any) *unstructured.Unstructured {
+	desired := &unstructured.Unstructured{}
+	desired.SetGroupVersionKind(rsipGVK)
+	desired.SetNamespace(namespace)
+	desired.SetName(name)
+	desired.SetLabels(lbls)
+	_ = unstructured.SetNestedField(desired.Object, map[string]any{
+		"type":          "Static",
+		"defaultValues": dv,
+	}, "spec")
+	return desired
+}
@@ -2,23 +2,24 @@
 package controller
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"maps"
-	"strings"
+	"slices"
+	"strconv"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -35,6 +36,96 @@ type SecretMirrorReconciler struct {
 
 	Opts      Options
 	allowedNS *threadSafeSet
+
+	// Optional enrichment (nil when disabled)
+	Prober       *clusterProber
+	Capabilities *capabilityDiscoverer
+	FleetInfo    *fleetInfoReader
+	CMDB         *cmdbPoller
+
+	// Optional kubeconfig replication into the RSIP namespace (nil when disabled)
+	Replicator *kubeconfigReplicator
+
+	// Optional sinks (nil when disabled)
+	ArgoOutput           *argoClusterWriter
+	ClusterProfileOutput *clusterProfileWriter
+
+	// Optional in-memory view of the fleet served by the inventory API (nil when disabled)
+	State *clusterState
+
+	// triggers lets background runnables requeue a Secret (wired as a channel source)
+	triggers chan event.GenericEvent
+	// sourceTriggers does the same for the other source controllers, by source kind
+	sourceTriggers map[string]chan event.GenericEvent
+}
+
+// isGenerated reports whether obj is labeled as a kubeconfig Secret the controller synthesized from another source.
+func isGenerated(obj client.Object) bool {
+	return obj.GetLabels()[generatedByLabel] != ""
+}
+
+// isTrustedGenerated reports whether obj is a generated Secret that may bypass the source
+// filters: its generating mode is enabled and it sits in that mode's target namespace.
+// Anyone can set the label, so a generated Secret anywhere else is filtered like any other.
+func isTrustedGenerated(obj client.Object, opts Options) bool {
+	switch obj.GetLabels()[generatedByLabel] {
+	case generatedByArgo:
+		return opts.ArgoCDSourceEnabled && obj.GetNamespace() == opts.ArgoCDTargetNamespace
+	case generatedByRemote:
+		return len(opts.RemoteKubeconfigs) > 0 && obj.GetNamespace() == opts.RemoteTargetNamespace
+	}
+	return false
+}
+
+// enqueue requests a reconcile of the given Secret from outside the watch.
+func (r *SecretMirrorReconciler) enqueue(nn types.NamespacedName) {
+	sec := &corev1.Secret{}
+	sec.SetNamespace(nn.Namespace)
+	sec.SetName(nn.Name)
+	r.triggers <- event.GenericEvent{Object: sec}
+}
+
+// sourceTrigger returns the channel that requeues objects of the given source kind, creating it
+// on first use. Call it during setup only.
+func (r *SecretMirrorReconciler) sourceTrigger(kind string) chan event.GenericEvent {
+	ch, ok := r.sourceTriggers[kind]
+	if !ok {
+		ch = make(chan event.GenericEvent, 1024)
+		r.sourceTriggers[kind] = ch
+	}
+	return ch
+}
+
+// requeueSource requests a reconcile of the source an RSIP was generated from, identified by
+// its reference labels like rsipSource. The local cluster RSIP is refreshed by its own resync.
+func (r *SecretMirrorReconciler) requeueSource(lbls map[string]string) {
+	trigger := func(kind string, nn types.NamespacedName) {
+		ch, ok := r.sourceTriggers[kind]
+		if !ok {
+			return // source not enabled
+		}
+		obj := &corev1.Secret{} // carrier only; the request is keyed by namespace/name
+		obj.SetNamespace(nn.Namespace)
+		obj.SetName(nn.Name)
+		ch <- event.GenericEvent{Object: obj}
+	}
+	switch {
+	case lbls[localClusterLabel] == "true":
+	case lbls[managedClusterLabel] != "":
+		trigger("managedCluster", types.NamespacedName{Name: lbls[managedClusterLabel]})
+	case lbls[inventoryEntryLabel] != "":
+		trigger("inventory", types.NamespacedName{Name: lbls[inventoryEntryLabel]})
+	case lbls[cmdbEntryLabel] != "":
+		trigger("cmdb", types.NamespacedName{Name: lbls[cmdbEntryLabel]})
+	case lbls["mirror.fluxcd.io/clusterProfileName"] != "":
+		trigger("clusterProfile", types.NamespacedName{
+			Namespace: lbls["mirror.fluxcd.io/clusterProfileNS"], Name: lbls["mirror.fluxcd.io/clusterProfileName"]})
+	case lbls["mirror.fluxcd.io/configMapName"] != "":
+		trigger("configMap", types.NamespacedName{
+			Namespace: lbls["mirror.fluxcd.io/configMapNS"], Name: lbls["mirror.fluxcd.io/configMapName"]})
+	case lbls["mirror.fluxcd.io/secretName"] != "":
+		r.enqueue(types.NamespacedName{Namespace: lbls["mirror.fluxcd.io/secretNS"], Name: lbls["mirror.fluxcd.io/secretName"]})
+	}
 }
 
 func (r *SecretMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconcile.Result, error) {
@@ -43,145 +134,287 @@ func (r *SecretMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	var sec corev1.Secret
 	if err := r.Get(ctx, req.NamespacedName, &sec); err != nil {
 		// Secret is gone — cleanup any RSIPs that referenced it
-		if err2 := r.ensureRSIPAbsence(ctx, req.NamespacedName); err2 != nil {
+		if err2 := r.ensureAbsence(ctx, req.NamespacedName); err2 != nil {
 			log.Error(err2, "cleanup after secret deletion failed")
 			return reconcile.Result{}, err2
 		}
+		r.State.forget(secretSource(req.NamespacedName))
 		log.V(1).Info("cleaned up after secret deletion")
 		return reconcile.Result{}, nil
 	}
 
-	// filters
-	if !r.allowedNS.Has(sec.Namespace) {
+	if isReplica(&sec) || isArgoOutput(&sec) {
+		return reconcile.Result{}, nil
+	}
+
+	// filters (Secrets we synthesized from another source bypass them;
+	// Cluster API kubeconfig Secrets are selected by their Cluster instead of labels)
+	generated := isTrustedGenerated(&sec, r.Opts)
+	capiSecret := r.Opts.CAPISourceEnabled && isCAPIKubeconfig(&sec)
+	if !generated && !r.allowedNS.Has(sec.Namespace) {
 		log.V(1).Info("namespace not in allowlist; ensuring cleanup", "namespace", sec.Namespace)
-		_ = r.ensureRSIPAbsence(ctx, req.NamespacedName)
+		_ = r.ensureAbsence(ctx, req.NamespacedName)
+		r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "namespace not allowed")
 		return reconcile.Result{}, nil
 	}
-	if !r.Opts.LabelSelector.Matches(labels.Set(sec.Labels)) {
+	if !generated && !capiSecret && !r.Opts.LabelSelector.Matches(labels.Set(sec.Labels)) {
 		log.V(1).Info("secret does not match label selector; ensuring cleanup",
 			"selector", r.Opts.LabelSelector.String())
-		_ = r.ensureRSIPAbsence(ctx, req.NamespacedName)
+		_ = r.ensureAbsence(ctx, req.NamespacedName)
+		r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "label selector does not match")
 		return reconcile.Result{}, nil
 	}
-	if _, ok := sec.Data[r.Opts.SecretKey]; !ok {
-		log.Info("secret missing kubeconfig key; skipping", "key", r.Opts.SecretKey)
+	secretKey := kubeconfigKey(&sec, r.Opts)
+	if _, ok := sec.Data[secretKey]; !ok {
+		log.Info("secret missing kubeconfig key; skipping", "key", secretKey)
+		r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "missing kubeconfig key "+secretKey)
 		return reconcile.Result{}, nil
 	}
 
-	// --- derive cluster/project for defaultValues (legacy behavior) ---
-	clusterName := sec.Labels[r.Opts.ClusterNameKey]
-	if clusterName == "" {
-		clusterName = sec.Name
-	}
-	if errs := validation.IsDNS1123Label(clusterName); len(errs) > 0 {
-		log.V(1).Info("sanitizing cluster name to DNS-1123", "errors", errs)
-		clusterName = sanitizeDNS1123(clusterName)
-	}
-	project := strings.TrimSpace(sec.Labels[r.Opts.ProjectLabelKey])
-	if project == "" {
-		project = projectFromNamespace(sec.Namespace)
+	// srcLabels are the labels the RSIP is derived from (Secret, overlaid by the CAPI Cluster)
+	srcLabels := sec.Labels
+	var capi *capiClusterInfo
+	if capiSecret {
+		info, found, err := getCAPICluster(ctx, r.Client, sec.Namespace, sec.Labels[capiClusterNameLabel])
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !found || !info.Ready {
+			log.V(1).Info("cluster api control plane not ready; ensuring cleanup", "found", found)
+			if err := r.ensureAbsence(ctx, req.NamespacedName); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "cluster api control plane not ready")
+			return reconcile.Result{}, nil
+		}
+		capi = &info
+		srcLabels = maps.Clone(sec.Labels)
+		maps.Copy(srcLabels, info.Labels)
 	}
-	project = sanitizeDNS1123(project)
 
-	// --- RSIP name: template (if provided) OR fallback to legacy "prefix+project-cluster" ---
-	var rsipName string
-	if r.Opts.RSIPNameTemplate != nil {
-		var buf bytes.Buffer
-		ctxObj := map[string]any{
-			"name":        sec.Name,
-			"namespace":   sec.Namespace,
-			"labels":      sec.Labels,
-			"annotations": sec.Annotations,
+	// vCluster Platform: enrich from the owning VirtualClusterInstance
+	var vci *vciInfo
+	if r.Opts.VCIEnrichmentEnabled {
+		info, found, err := lookupVCI(ctx, r.Client, sec.Labels)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
-		if err := r.Opts.RSIPNameTemplate.Execute(&buf, ctxObj); err != nil {
-			log.Error(err, "rsip-name-template execution failed; falling back to project/cluster")
-		} else if out := strings.TrimSpace(buf.String()); out != "" {
-			rsipName = r.Opts.RSIPNamePrefix + out
+		if found {
+			if info.Sleeping && r.Opts.VCISleepPolicy == VCISleepPolicyWithdraw {
+				log.Info("virtual cluster instance is sleeping; withdrawing RSIP", "vci", info.Namespace+"/"+info.Name)
+				// the Argo CD and ClusterProfile outputs go too, or they would keep targeting it
+				if err := r.ensureAbsence(ctx, req.NamespacedName); err != nil {
+					return reconcile.Result{}, err
+				}
+				r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "virtual cluster instance sleeping")
+				return reconcile.Result{}, nil
+			}
+			vci = &info
+			srcLabels = maps.Clone(srcLabels)
+			for k, v := range info.Labels {
+				if _, ok := srcLabels[k]; !ok { // labels on the Secret win
+					srcLabels[k] = v
+				}
+			}
 		}
 	}
-	if rsipName == "" {
-		// legacy fallback
-		rsipName = r.Opts.RSIPNamePrefix
-		if project != "" {
-			rsipName += project + "-"
+	if r.Prober != nil && r.Prober.withdraw(req.NamespacedName) {
+		log.Info("cluster unreachable past threshold; withdrawing RSIP",
+			"threshold", r.Opts.ProbeUnreachableThreshold.String())
+		// the Argo CD and ClusterProfile outputs go too, or they would keep targeting it
+		if err := r.ensureAbsence(ctx, req.NamespacedName); err != nil {
+			return reconcile.Result{}, err
 		}
-		rsipName += clusterName
-	}
-	if len(rsipName) > 253 {
-	    rsipName = rsipName[:253]
-	}
-	log.V(1).Info("computed RSIP name", "rsipName", rsipName, "templated", r.Opts.RSIPNameTemplate != nil)
-
-	// desired RSIP
-	desired := &unstructured.Unstructured{}
-	desired.SetGroupVersionKind(rsipGVK)
-	desired.SetNamespace(r.Opts.RSIPNamespace)
-	desired.SetName(rsipName)
-
-	lbls := map[string]string{
-		"mirror.fluxcd.io/managed":     "true",
-		"mirror.fluxcd.io/secretNS":    sec.Namespace,
-		"mirror.fluxcd.io/secretName":  sec.Name,
-		"mirror.fluxcd.io/secretKey":   r.Opts.SecretKey,
-		"mirror.fluxcd.io/clusterName": clusterName,
-		"mirror.fluxcd.io/project":     project,
-	}
-	for _, k := range r.Opts.CopyLabelKeys {
-		if v, ok := sec.Labels[k]; ok {
-			lbls[k] = v
+		r.State.skipped(secretSource(req.NamespacedName), sec.Name, sec.Labels, "cluster unreachable")
+		return reconcile.Result{}, nil
+	}
+
+	in := rsipInput{
+		Name:        sec.Name,
+		Namespace:   sec.Namespace,
+		Labels:      srcLabels,
+		Annotations: sec.Annotations,
+		RefLabels: map[string]string{
+			"mirror.fluxcd.io/secretNS":   sec.Namespace,
+			"mirror.fluxcd.io/secretName": sec.Name,
+			"mirror.fluxcd.io/secretKey":  secretKey,
+		},
+	}
+	if capi != nil {
+		in.ClusterName = capi.Name
+	}
+	if vci != nil {
+		in.ProjectNS = vci.Namespace
+	}
+	if generated && sec.Labels[generatedByLabel] == generatedByRemote {
+		in.SourceCluster = sec.Labels[sourceClusterLabel]
+		// the copy lives in --remote-target-namespace; the project comes from where it was copied from
+		if in.ProjectNS == "" {
+			in.ProjectNS = sec.Labels["mirror.fluxcd.io/remoteSecretNS"]
 		}
 	}
-	for k, v := range sec.Labels {
-		if hasAnyPrefix(r.Opts.CopyLabelPrefixes, k) {
-			lbls[k] = v
+
+	// one RSIP namespace, or one RSIP per fan-out target with its label overrides
+	targets := []string{r.rsipNamespace(log, in)}
+	var overrides map[string]map[string]string
+	if r.Opts.fanoutEnabled() {
+		var err error
+		if overrides, err = r.fanoutTargets(ctx, log); err != nil {
+			return reconcile.Result{}, err
 		}
+		targets = slices.Sorted(maps.Keys(overrides))
 	}
-	desired.SetLabels(lbls)
 
-	dv := map[string]any{
-		"name":           clusterName,
-		"project":        project,
+	// Flux's kubeConfig.secretRef cannot cross namespaces: point each RSIP at a replica in its own
+	kubeSecrets := map[string]types.NamespacedName{}
+	if r.Replicator != nil {
+		var err error
+		if kubeSecrets, err = r.Replicator.ensure(ctx, &sec, secretKey, targets...); err != nil {
+			log.Error(err, "kubeconfig replication failed")
+			return reconcile.Result{}, err
+		}
+	}
+	in.RefValues = map[string]any{
 		"kubeSecretName": sec.Name,
-		"kubeSecretKey":  r.Opts.SecretKey,
+		"kubeSecretKey":  secretKey,
 		"kubeSecretNS":   sec.Namespace,
 	}
-	reserved := sets.New[string]("name", "project", "kubeSecretName", "kubeSecretKey", "kubeSecretNS")
-	for _, k := range r.Opts.CopyLabelKeys {
-		if v, ok := sec.Labels[k]; ok {
-			ck := toCamel(k)
-			if !reserved.Has(ck) {
-				dv[ck] = v
-			}
+	rsip, clusterName, lbls, dv := r.rsipSkeleton(log, in)
+
+	if capi != nil {
+		lbls["mirror.fluxcd.io/capiCluster"] = sanitizeLabelValue(capi.Name)
+		if capi.TopologyVersion != "" {
+			lbls["mirror.fluxcd.io/kubernetesVersion"] = sanitizeLabelValue(capi.TopologyVersion)
+			dv["kubernetesVersion"] = capi.TopologyVersion
+		}
+		if capi.InfrastructureKind != "" {
+			lbls["mirror.fluxcd.io/infrastructureKind"] = sanitizeLabelValue(capi.InfrastructureKind)
+			dv["infrastructureKind"] = capi.InfrastructureKind
+		}
+	}
+	if in.SourceCluster != "" {
+		lbls[sourceClusterLabel] = in.SourceCluster
+		dv["sourceCluster"] = in.SourceCluster
+	}
+	if vci != nil {
+		if vci.TemplateName != "" {
+			lbls["mirror.fluxcd.io/vciTemplate"] = sanitizeLabelValue(vci.TemplateName)
+			dv["vciTemplate"] = vci.TemplateName
+		}
+		if vci.KubernetesVersion != "" {
+			lbls["mirror.fluxcd.io/kubernetesVersion"] = sanitizeLabelValue(vci.KubernetesVersion)
+			dv["kubernetesVersion"] = vci.KubernetesVersion
+		}
+		lbls["mirror.fluxcd.io/sleeping"] = strconv.FormatBool(vci.Sleeping)
+		dv["sleeping"] = strconv.FormatBool(vci.Sleeping)
+	}
+	if r.Prober != nil {
+		r.Prober.apply(req.NamespacedName, lbls, dv)
+	}
+	if r.Capabilities != nil {
+		r.Capabilities.apply(req.NamespacedName, lbls, dv)
+	}
+	// last, so everything the hub already set wins over the cluster's own data
+	if r.FleetInfo != nil {
+		r.FleetInfo.apply(req.NamespacedName, lbls, dv)
+	}
+
+	// the cluster RSIP, or one RSIP per enabled app and/or matrix entry
+	variants := []rsipVariant{{}}
+	if r.Opts.AppFanout {
+		variants = appVariants(r.Opts.AppLabelPrefix, srcLabels, sec.Annotations)
+	}
+	keep := make([]types.NamespacedName, 0, len(targets)*len(variants))
+	entries, ok, err := r.matrixVariants(ctx, &sec)
+	switch {
+	case errors.Is(err, errMatrixUnavailable):
+		// keep the current matrix RSIPs as they are; the sinks below still follow the Secret
+		log.Info("matrix unavailable; keeping its RSIPs", "reason", err.Error())
+		variants = nil
+		kept, err := r.matrixRSIPs(ctx, lbls)
+		if err != nil {
+			return reconcile.Result{}, err
 		}
+		keep = append(keep, kept...)
+	case err != nil:
+		return reconcile.Result{}, err
+	case ok:
+		variants = crossVariants(variants, entries)
 	}
-	for k, v := range sec.Labels {
-		if hasAnyPrefix(r.Opts.CopyLabelPrefixes, k) {
-			ck := toCamel(k)
-			if !reserved.Has(ck) {
-				dv[ck] = v
+
+	for _, ns := range targets {
+		tl, tdv := lbls, dv
+		if o := overrides[ns]; len(o) > 0 {
+			tl = withFanoutOverrides(lbls, o)
+		}
+		if ks, ok := kubeSecrets[ns]; ok {
+			tdv = maps.Clone(dv)
+			tdv["kubeSecretName"], tdv["kubeSecretNS"] = ks.Name, ks.Namespace
+		}
+		for _, v := range variants {
+			name, vl, vdv := v.apply(rsip.Name, tl, tdv)
+			desired := newRSIP(ns, name, vl, vdv)
+			if err := r.applyRSIP(ctx, log, &sec, desired); err != nil {
+				return reconcile.Result{}, err
 			}
+			keep = append(keep, client.ObjectKeyFromObject(desired))
 		}
 	}
+	r.recordServer(log, lbls, &sec, secretKey)
+	// also collects the RSIPs of fan-out namespaces that dropped out and of disabled apps
+	if err := r.pruneRSIPs(ctx, log, lbls, keep...); err != nil {
+		return reconcile.Result{}, err
+	}
 
-	_ = unstructured.SetNestedField(desired.Object, map[string]any{
-		"type":          "Static",
-		"defaultValues": dv,
-	}, "spec")
+	// optional sinks beside the RSIP
+	if r.ArgoOutput != nil && sec.Labels[generatedByLabel] != generatedByArgo {
+		if err := r.ArgoOutput.ensure(ctx, &sec, clusterName, lbls); err != nil {
+			log.Error(err, "argo cd cluster secret sync failed")
+			return reconcile.Result{}, err
+		}
+	}
+	if r.ClusterProfileOutput != nil {
+		if err := r.ClusterProfileOutput.ensure(ctx, &sec, clusterName, lbls); err != nil {
+			log.Error(err, "cluster profile sync failed")
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// rsipFieldManager owns the labels and spec of the RSIPs; fleet summaries date an RSIP by it.
+const rsipFieldManager = "flux-cluster-generator"
+
+// applyRSIP creates desired or updates the existing RSIP when labels or spec drifted.
+// Events are recorded on owner (the source object).
+func (r *SecretMirrorReconciler) applyRSIP(ctx context.Context, log logr.Logger, owner client.Object, desired *unstructured.Unstructured) error {
+	rsipNS, rsipName := desired.GetNamespace(), desired.GetName()
 
-	// create/update
 	var existing unstructured.Unstructured
 	existing.SetGroupVersionKind(rsipGVK)
-	if err := r.Get(ctx, types.NamespacedName{Name: rsipName, Namespace: r.Opts.RSIPNamespace}, &existing); err != nil {
-		if err := r.Create(ctx, desired); err != nil {
-			r.Recorder.Eventf(&sec, corev1.EventTypeWarning, "RSIPCreateFailed",
-				"failed to create RSIP %s/%s: %v", r.Opts.RSIPNamespace, rsipName, err)
-			log.Error(err, "create RSIP failed", "name", rsipName, "ns", r.Opts.RSIPNamespace)
-			return reconcile.Result{}, err
+	if err := r.Get(ctx, types.NamespacedName{Name: rsipName, Namespace: rsipNS}, &existing); err != nil {
+		if err := r.Create(ctx, desired, client.FieldOwner(rsipFieldManager)); err != nil {
+			r.Recorder.Eventf(owner, corev1.EventTypeWarning, "RSIPCreateFailed",
+				"failed to create RSIP %s/%s: %v", rsipNS, rsipName, err)
+			log.Error(err, "create RSIP failed", "name", rsipName, "ns", rsipNS)
+			return err
 		}
-		r.Recorder.Eventf(&sec, corev1.EventTypeNormal, "RSIPCreated",
-			"created RSIP %s/%s", r.Opts.RSIPNamespace, rsipName)
-		log.Info("created RSIP", "name", rsipName, "ns", r.Opts.RSIPNamespace)
-		return reconcile.Result{}, nil
+		r.Recorder.Eventf(owner, corev1.EventTypeNormal, "RSIPCreated",
+			"created RSIP %s/%s", rsipNS, rsipName)
+		log.Info("created RSIP", "name", rsipName, "ns", rsipNS)
+		r.State.applied(desired)
+		return nil
+	}
+
+	// two sources mapping to the same name would overwrite each other on every reconcile
+	if !sameSourceRef(existing.GetLabels(), desired.GetLabels()) {
+		other, mine := rsipSource(existing.GetLabels()), rsipSource(desired.GetLabels())
+		if other == "" {
+			other = "an RSIP not managed by the generator"
+		}
+		r.Recorder.Eventf(owner, corev1.EventTypeWarning, "RSIPConflict",
+			"RSIP %s/%s already belongs to %s; not overwriting it for %s", rsipNS, rsipName, other, mine)
+		return fmt.Errorf("RSIP %s/%s belongs to %s", rsipNS, rsipName, other)
 	}
 
 	changed := false
@@ -196,42 +429,93 @@ func (r *SecretMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		changed = true
 	}
 	if changed {
-		if err := r.Update(ctx, &existing); err != nil {
-			r.Recorder.Eventf(&sec, corev1.EventTypeWarning, "RSIPUpdateFailed",
-				"failed to update RSIP %s/%s: %v", r.Opts.RSIPNamespace, rsipName, err)
+		if err := r.Update(ctx, &existing, client.FieldOwner(rsipFieldManager)); err != nil {
+			r.Recorder.Eventf(owner, corev1.EventTypeWarning, "RSIPUpdateFailed",
+				"failed to update RSIP %s/%s: %v", rsipNS, rsipName, err)
 			log.Error(err, "update RSIP failed", "name", rsipName)
-			return reconcile.Result{}, err
+			return err
 		}
-		r.Recorder.Eventf(&sec, corev1.EventTypeNormal, "RSIPUpdated",
-			"updated RSIP %s/%s", r.Opts.RSIPNamespace, rsipName)
+		r.Recorder.Eventf(owner, corev1.EventTypeNormal, "RSIPUpdated",
+			"updated RSIP %s/%s", rsipNS, rsipName)
 		log.Info("updated RSIP", "name", rsipName)
 	} else {
 		log.V(1).Info("RSIP up-to-date", "name", rsipName)
 	}
-	return reconcile.Result{}, nil
+	r.State.applied(desired)
+	return nil
+}
+
+// pruneRSIPs deletes the RSIPs of the source identified by lbls other than keep: those left
+// under another name or namespace when the naming templates, prefix, source labels or
+// fan-out targets changed.
+func (r *SecretMirrorReconciler) pruneRSIPs(ctx context.Context, log logr.Logger, lbls map[string]string, keep ...types.NamespacedName) error {
+	sel, ok := sourceRef(lbls)
+	if !ok {
+		return nil
+	}
+	r.State.retain(lbls, keep...)
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
+	})
+	if err := r.APIReader.List(ctx, &list, managedRSIPs(sel)); err != nil {
+		return fmt.Errorf("list RSIPs for pruning: %w", err)
+	}
+	for i := range list.Items {
+		rsip := &list.Items[i]
+		if slices.Contains(keep, client.ObjectKeyFromObject(rsip)) {
+			continue
+		}
+		if err := r.Delete(ctx, rsip); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		log.Info("deleted superseded RSIP", "name", rsip.GetName(), "ns", rsip.GetNamespace())
+	}
+	return nil
+}
+
+// ensureAbsence removes everything generated for a Secret that is gone or no longer qualifies.
+func (r *SecretMirrorReconciler) ensureAbsence(ctx context.Context, secretNN types.NamespacedName) error {
+	err := r.ensureRSIPAbsence(ctx, secretNN)
+	if r.ArgoOutput != nil {
+		err = errors.Join(err, r.ArgoOutput.remove(ctx, secretNN))
+	}
+	if r.ClusterProfileOutput != nil {
+		err = errors.Join(err, r.ClusterProfileOutput.remove(ctx, secretNN))
+	}
+	return err
 }
 
 func (r *SecretMirrorReconciler) ensureRSIPAbsence(ctx context.Context, secretNN types.NamespacedName) error {
+	// only the Secret's own RSIPs: OCM, inventory and CMDB RSIPs name their Secret too
+	sel, _ := sourceRef(map[string]string{
+		"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
+		"mirror.fluxcd.io/secretName": secretNN.Name,
+	})
+	err := r.deleteRSIPsFor(ctx, "secret", secretNN, sel)
+	// replicas go with the RSIP
+	if r.Replicator != nil {
+		err = errors.Join(err, r.Replicator.remove(ctx, secretNN))
+	}
+	return err
+}
+
+// deleteRSIPsFor deletes the RSIPs whose source reference labels match; kind names the source in logs.
+func (r *SecretMirrorReconciler) deleteRSIPsFor(ctx context.Context, kind string, src types.NamespacedName, match labels.Selector) error {
 	log := ctrl.Log.WithName("gc")
 
-	// List by labels (works even when the Secret is already gone)
+	// List by labels (works even when the source is already gone)
 	var list unstructured.UnstructuredList
 	list.SetGroupVersionKind(schema.GroupVersionKind{
 		Group: rsipGVK.Group, Version: rsipGVK.Version, Kind: rsipGVK.Kind + "List",
 	})
-	if err := r.APIReader.List(ctx, &list,
-		client.InNamespace(r.Opts.RSIPNamespace),
-		client.MatchingLabels{
-			"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
-			"mirror.fluxcd.io/secretName": secretNN.Name,
-		},
-	); err != nil {
-		log.Error(err, "list RSIPs for cleanup failed", "secret", secretNN.String())
+	if err := r.APIReader.List(ctx, &list, managedRSIPs(match)); err != nil {
+		log.Error(err, "list RSIPs for cleanup failed", kind, src.String())
 		return err
 	}
 
 	if len(list.Items) == 0 {
-		log.V(1).Info("no RSIPs to delete for secret", "secret", secretNN.String())
+		log.V(1).Info("no RSIPs to delete for "+kind, kind, src.String())
 		return nil
 	}
 
@@ -244,12 +528,13 @@ func (r *SecretMirrorReconciler) ensureRSIPAbsence(ctx context.Context, secretNN
 			log.Error(err, "delete RSIP failed", "name", rsip.GetName())
 		} else {
 			deleted++
+			r.State.deleted(rsip.GetLabels(), client.ObjectKeyFromObject(rsip))
 			log.Info("deleted RSIP", "name", rsip.GetName())
 		}
 	}
-	// No Eventf here: we don't have a runtime.Object for a deleted Secret.
+	// No Eventf here: we don't have a runtime.Object for a deleted source.
 	if deleted > 0 {
-		log.Info("deleted RSIPs for secret", "secret", secretNN.String(), "count", deleted)
+		log.Info("deleted RSIPs for "+kind, kind, src.String(), "count", deleted)
 	}
 	if len(errs) > 0 {
 		return fmt.Errorf("cleanup had %d error(s), deleted=%d", len(errs), deleted)
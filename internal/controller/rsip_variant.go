@@ -0,0 +1,101 @@
+// internal/controller/rsip_variant.go
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// variantReservedValues identify the cluster and the variant; scoped values never replace them.
+var variantReservedValues = sets.New("name", "project", "app", "matrix", "matrixEntry",
+	"kubeSecretName", "kubeSecretKey", "kubeSecretNS")
+
+// rsipVariant is one of the RSIPs generated for a single source and target namespace: the
+// cluster RSIP itself (the zero value), one per enabled app (--app-fanout), one per matrix
+// entry, or their combinations.
+type rsipVariant struct {
+	suffixes []string          // appended to the RSIP name, in order
+	labels   map[string]string // set on the RSIP
+	ids      map[string]any    // defaultValues identifying the variant ("app", "matrixEntry")
+	values   map[string]string // scoped defaultValues (app annotations, matrix entry values)
+}
+
+// cross combines two variants; o's labels and values win.
+func (v rsipVariant) cross(o rsipVariant) rsipVariant {
+	out := rsipVariant{
+		suffixes: append(append([]string(nil), v.suffixes...), o.suffixes...),
+		labels:   maps.Clone(v.labels),
+		ids:      maps.Clone(v.ids),
+		values:   maps.Clone(v.values),
+	}
+	if out.labels == nil {
+		out.labels = map[string]string{}
+	}
+	if out.ids == nil {
+		out.ids = map[string]any{}
+	}
+	if out.values == nil {
+		out.values = map[string]string{}
+	}
+	maps.Copy(out.labels, o.labels)
+	maps.Copy(out.ids, o.ids)
+	maps.Copy(out.values, o.values)
+	return out
+}
+
+// crossVariants returns every combination of a and b, ordered by a then b.
+func crossVariants(a, b []rsipVariant) []rsipVariant {
+	out := make([]rsipVariant, 0, len(a)*len(b))
+	for _, x := range a {
+		for _, y := range b {
+			out = append(out, x.cross(y))
+		}
+	}
+	return out
+}
+
+// apply returns the RSIP name, labels and defaultValues of the variant derived from the
+// cluster's; the inputs are not modified.
+func (v rsipVariant) apply(name string, lbls map[string]string, dv map[string]any) (string, map[string]string, map[string]any) {
+	if len(v.suffixes) == 0 && len(v.labels) == 0 && len(v.ids) == 0 && len(v.values) == 0 {
+		return name, lbls, dv
+	}
+	// a name that ends like another source's RSIP (Secret "foo" with app "bar" vs. Secret
+	// "foo-bar") is refused by applyRSIP's conflict check, so the suffix stays readable
+	suffix := ""
+	for _, s := range v.suffixes {
+		suffix += "-" + sanitizeDNS1123(s)
+	}
+	if len(name)+len(suffix) > 253 {
+		// truncating the cluster part could merge variants of different clusters
+		suffix += "-" + v.idHash()
+		name = name[:max(0, 253-len(suffix))]
+	}
+	name += suffix
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	lbls = maps.Clone(lbls)
+	maps.Copy(lbls, v.labels)
+	dv = maps.Clone(dv)
+	for k, val := range v.values {
+		if ck := toCamel(k); !variantReservedValues.Has(ck) {
+			dv[ck] = val
+		}
+	}
+	maps.Copy(dv, v.ids)
+	return name, lbls, dv
+}
+
+// idHash is a short, stable hash of the variant's IDs.
+func (v rsipVariant) idHash() string {
+	h := fnv.New32a()
+	for _, k := range slices.Sorted(maps.Keys(v.ids)) {
+		fmt.Fprintf(h, "%s=%v;", k, v.ids[k])
+	}
+	return fmt.Sprintf("%08x", h.Sum32())
+}
@@ -0,0 +1,146 @@
+// internal/controller/secret_replica.go
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// replicaLabel marks kubeconfig Secrets replicated into the RSIP namespace.
+const replicaLabel = "mirror.fluxcd.io/replica"
+
+// isReplica reports whether obj is a kubeconfig replica written by the controller.
+func isReplica(obj client.Object) bool {
+	return obj.GetLabels()[replicaLabel] == "true"
+}
+
+// kubeconfigReplicator copies the kubeconfig key of a source Secret into the namespace its
+// RSIP (and so the ResourceSets' HelmReleases) live in, because Flux's kubeConfig.secretRef
+// cannot cross namespaces.
+type kubeconfigReplicator struct {
+	Client    client.Client
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+}
+
+// ensure keeps a replica of sec.Data[key] in each target namespace in sync and returns, per
+// target, the Secret the RSIPs there should reference: sec itself when it already lives in
+// the target namespace, the replica otherwise.
+func (w *kubeconfigReplicator) ensure(ctx context.Context, sec *corev1.Secret, key string, targetNSs ...string) (map[string]types.NamespacedName, error) {
+	out := make(map[string]types.NamespacedName, len(targetNSs))
+	keep := sets.New[types.NamespacedName]()
+	for _, target := range targetNSs {
+		if target == sec.Namespace {
+			out[target] = client.ObjectKeyFromObject(sec)
+			continue
+		}
+		nn, err := w.write(ctx, sec, key, target)
+		if err != nil {
+			return out, err
+		}
+		out[target] = nn
+		keep.Insert(nn)
+	}
+	// the target namespaces may have changed
+	return out, w.removeExcept(ctx, client.ObjectKeyFromObject(sec), keep)
+}
+
+// write creates or updates the replica of sec.Data[key] in targetNS.
+func (w *kubeconfigReplicator) write(ctx context.Context, sec *corev1.Secret, key, targetNS string) (types.NamespacedName, error) {
+	log := ctrl.Log.WithName("replica").WithValues("secret", client.ObjectKeyFromObject(sec).String())
+
+	desired := &corev1.Secret{}
+	desired.SetNamespace(targetNS)
+	desired.SetName(replicaName(sec))
+	desired.SetLabels(map[string]string{
+		replicaLabel:                  "true",
+		"mirror.fluxcd.io/secretNS":   sec.Namespace,
+		"mirror.fluxcd.io/secretName": sec.Name,
+	})
+	desired.Type = corev1.SecretTypeOpaque
+	desired.Data = map[string][]byte{key: sec.Data[key]}
+	nn := client.ObjectKeyFromObject(desired)
+
+	var existing corev1.Secret
+	err := w.Client.Get(ctx, nn, &existing)
+	switch {
+	case client.IgnoreNotFound(err) != nil:
+		return nn, err
+	case err != nil:
+		if err := w.Client.Create(ctx, desired); err != nil {
+			w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ReplicaCreateFailed",
+				"failed to create kubeconfig replica %s: %v", nn, err)
+			return nn, err
+		}
+		w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ReplicaCreated", "created kubeconfig replica %s", nn)
+		log.Info("created kubeconfig replica", "replica", nn.String())
+	case existing.Labels[replicaLabel] != "true":
+		return nn, fmt.Errorf("secret %s exists and is not a replica; not overwriting it", nn)
+	case existing.Labels["mirror.fluxcd.io/secretNS"] != sec.Namespace ||
+		existing.Labels["mirror.fluxcd.io/secretName"] != sec.Name:
+		// another source's replica; taking it over would point that cluster's RSIPs at these credentials
+		other := existing.Labels["mirror.fluxcd.io/secretNS"] + "/" + existing.Labels["mirror.fluxcd.io/secretName"]
+		w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ReplicaConflict",
+			"kubeconfig replica %s already belongs to Secret %s; not overwriting it", nn, other)
+		return nn, fmt.Errorf("kubeconfig replica %s belongs to Secret %s", nn, other)
+	case len(existing.Data) != 1 || !bytes.Equal(existing.Data[key], desired.Data[key]):
+		existing.Labels = desired.Labels
+		existing.Data = desired.Data
+		if err := w.Client.Update(ctx, &existing); err != nil {
+			w.Recorder.Eventf(sec, corev1.EventTypeWarning, "ReplicaUpdateFailed",
+				"failed to update kubeconfig replica %s: %v", nn, err)
+			return nn, err
+		}
+		w.Recorder.Eventf(sec, corev1.EventTypeNormal, "ReplicaUpdated", "updated kubeconfig replica %s", nn)
+		log.Info("updated kubeconfig replica", "replica", nn.String())
+	default:
+		log.V(1).Info("kubeconfig replica up-to-date", "replica", nn.String())
+	}
+	return nn, nil
+}
+
+// remove deletes every replica of a source Secret.
+func (w *kubeconfigReplicator) remove(ctx context.Context, secretNN types.NamespacedName) error {
+	return w.removeExcept(ctx, secretNN, nil)
+}
+
+// removeExcept deletes the replicas of a source Secret not in keep.
+func (w *kubeconfigReplicator) removeExcept(ctx context.Context, secretNN types.NamespacedName, keep sets.Set[types.NamespacedName]) error {
+	var list corev1.SecretList
+	if err := w.APIReader.List(ctx, &list, client.MatchingLabels{
+		replicaLabel:                  "true",
+		"mirror.fluxcd.io/secretNS":   secretNN.Namespace,
+		"mirror.fluxcd.io/secretName": secretNN.Name,
+	}); err != nil {
+		return fmt.Errorf("list kubeconfig replicas: %w", err)
+	}
+	for i := range list.Items {
+		if keep.Has(client.ObjectKeyFromObject(&list.Items[i])) {
+			continue
+		}
+		if err := w.Client.Delete(ctx, &list.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		ctrl.Log.WithName("replica").Info("deleted kubeconfig replica",
+			"replica", client.ObjectKeyFromObject(&list.Items[i]).String(), "secret", secretNN.String())
+	}
+	return nil
+}
+
+// replicaName is the name of the replica of a source Secret. The namespace is a DNS label,
+// so the dots keep every namespace/name combination distinct.
+func replicaName(sec *corev1.Secret) string {
+	name := "kubeconfig." + sec.Namespace + "." + sec.Name
+	if len(name) > 253 {
+		name = name[:253]
+	}
+	return name
+}
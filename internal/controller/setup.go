@@ -4,20 +4,27 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/client-go/util/workqueue"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // NewRSIPReconciler maps Options into the reconciler.
@@ -28,6 +35,9 @@ func NewRSIPReconciler(c client.Client, r client.Reader, opts Options) *SecretMi
 		Recorder:  nil,             // will be set by mgr in SetupRSIPController
 		Opts:      opts,
 		allowedNS: newThreadSafeSet(),
+		triggers:  make(chan event.GenericEvent, 1024),
+
+		sourceTriggers: map[string]chan event.GenericEvent{},
 	}
 }
 
@@ -81,27 +91,94 @@ func SetupRSIPController(mgr manager.Manager, opts Options) error {
 	watchSet := toStringSet(opts.WatchNamespaces)
 
 	// Secret watch (allow deletes for cleanup)
+	secMatches := func(obj client.Object) bool {
+		if isTrustedGenerated(obj, opts) {
+			return true
+		}
+		if isReplica(obj) || isArgoOutput(obj) {
+			return false // written from a source, never a source itself
+		}
+		selected := opts.LabelSelector.Matches(labels.Set(obj.GetLabels())) ||
+			(opts.CAPISourceEnabled && isCAPIKubeconfig(obj))
+		return (watchSet.Len() == 0 || watchSet.Has(obj.GetNamespace())) &&
+			selected &&
+			rec.allowedNS.Has(obj.GetNamespace())
+	}
 	secPred := predicate.Funcs{
-		CreateFunc: func(e event.CreateEvent) bool {
-			return (watchSet.Len() == 0 || watchSet.Has(e.Object.GetNamespace())) &&
-				opts.LabelSelector.Matches(labels.Set(e.Object.GetLabels())) &&
-				rec.allowedNS.Has(e.Object.GetNamespace())
-		},
-		UpdateFunc: func(e event.UpdateEvent) bool {
-			return (watchSet.Len() == 0 || watchSet.Has(e.ObjectNew.GetNamespace())) &&
-				opts.LabelSelector.Matches(labels.Set(e.ObjectNew.GetLabels())) &&
-				rec.allowedNS.Has(e.ObjectNew.GetNamespace())
-		},
+		CreateFunc:  func(e event.CreateEvent) bool { return secMatches(e.Object) },
+		UpdateFunc:  func(e event.UpdateEvent) bool { return secMatches(e.ObjectNew) },
 		DeleteFunc:  func(e event.DeleteEvent) bool { return true },
-		GenericFunc: func(e event.GenericEvent) bool {
-			return (watchSet.Len() == 0 || watchSet.Has(e.Object.GetNamespace())) &&
-				opts.LabelSelector.Matches(labels.Set(e.Object.GetLabels())) &&
-				rec.allowedNS.Has(e.Object.GetNamespace())
-		},
+		GenericFunc: func(e event.GenericEvent) bool { return secMatches(e.Object) },
 	}
 
-	if err := ctrl.NewControllerManagedBy(mgr).
+	// Optional reachability prober
+	if opts.ProbeEnabled {
+		rec.Prober = newClusterProber(mgr.GetClient(), rec.Recorder, opts, secMatches, rec.enqueue)
+		if err := mgr.Add(rec.Prober); err != nil {
+			return fmt.Errorf("add prober: %w", err)
+		}
+		log.Info("cluster reachability probing enabled",
+			"interval", opts.ProbeInterval.String(), "policy", opts.ProbeUnreachablePolicy)
+	}
+
+	// Optional capability discovery
+	if opts.CapabilitiesEnabled {
+		rec.Capabilities = newCapabilityDiscoverer(mgr.GetClient(), rec.Recorder, opts, secMatches, rec.enqueue)
+		if err := mgr.Add(rec.Capabilities); err != nil {
+			return fmt.Errorf("add capability discoverer: %w", err)
+		}
+		log.Info("capability discovery enabled",
+			"interval", opts.CapabilityInterval.String(), "capabilities", len(opts.Capabilities))
+	}
+
+	// Optional self-described metadata from inside each cluster
+	if opts.FleetInfoConfigMap != "" {
+		rec.FleetInfo = newFleetInfoReader(mgr.GetClient(), rec.Recorder, opts, secMatches, rec.enqueue)
+		if err := mgr.Add(rec.FleetInfo); err != nil {
+			return fmt.Errorf("add fleet info reader: %w", err)
+		}
+		log.Info("fleet info enabled", "configMap", opts.FleetInfoConfigMap, "keys", opts.FleetInfoKeys)
+	}
+
+	secBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Secret{}, builder.WithPredicates(secPred)).
+		WatchesRawSource(source.Channel(rec.triggers, &handler.EnqueueRequestForObject{}))
+
+	// Cluster API: a Cluster change (readiness, labels, version) re-reconciles its kubeconfig Secret
+	if opts.CAPISourceEnabled {
+		capiCluster := &unstructured.Unstructured{}
+		capiCluster.SetGroupVersionKind(capiClusterGVK)
+		secBuilder = secBuilder.Watches(capiCluster, handler.EnqueueRequestsFromMapFunc(capiClusterToSecret))
+		log.Info("cluster api source enabled")
+	}
+
+	// vCluster Platform: instance changes (labels, sleep mode) re-reconcile their Secrets
+	if opts.VCIEnrichmentEnabled {
+		vci := &unstructured.Unstructured{}
+		vci.SetGroupVersionKind(vciGVK)
+		secBuilder = secBuilder.Watches(vci, handler.EnqueueRequestsFromMapFunc(vciToSecrets(mgr.GetClient())))
+		log.Info("virtual cluster instance enrichment enabled", "sleepPolicy", opts.VCISleepPolicy)
+	}
+
+	// Fan-out: targets appearing, disappearing or changing their overrides re-reconcile every Secret
+	if opts.fanoutEnabled() {
+		secBuilder = secBuilder.Watches(&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(fanoutToSecrets(mgr.GetClient(), secMatches)),
+			builder.WithPredicates(fanoutNamespacePredicate(opts)))
+		log.Info("rsip fan-out enabled", "namespaces", opts.FanoutNamespaces, "selector", opts.FanoutNamespaceSelectorStr)
+	}
+
+	// Matrix: a list ConfigMap change re-reconciles the Secrets crossed with it
+	if opts.MatrixNamespace != "" {
+		secBuilder = secBuilder.Watches(&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(matrixToSecrets(mgr.GetClient())),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetNamespace() == opts.MatrixNamespace
+			})))
+		log.Info("matrix enabled", "namespace", opts.MatrixNamespace)
+	}
+
+	if err := secBuilder.
 		WithOptions(controller.Options{
 			CacheSyncTimeout:        opts.CacheSyncTimeout,
 			RecoverPanic:            boolPtr(true),
@@ -112,13 +189,361 @@ func SetupRSIPController(mgr manager.Manager, opts Options) error {
 		return err
 	}
 
+	// Optional workload-identity kubeconfig ConfigMap source
+	if opts.ConfigMapSelector != nil {
+		cmMatches := func(obj client.Object) bool {
+			return (watchSet.Len() == 0 || watchSet.Has(obj.GetNamespace())) &&
+				opts.ConfigMapSelector.Matches(labels.Set(obj.GetLabels())) &&
+				rec.allowedNS.Has(obj.GetNamespace())
+		}
+		cmPred := predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool { return cmMatches(e.Object) },
+			// old object too, so a ConfigMap that stops matching gets cleaned up
+			UpdateFunc:  func(e event.UpdateEvent) bool { return cmMatches(e.ObjectOld) || cmMatches(e.ObjectNew) },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+			GenericFunc: func(e event.GenericEvent) bool { return cmMatches(e.Object) },
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("kubeconfig-configmap").
+			For(&corev1.ConfigMap{}, builder.WithPredicates(cmPred)).
+			WatchesRawSource(source.Channel(rec.sourceTrigger("configMap"), &handler.EnqueueRequestForObject{})).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: opts.MaxConcurrent,
+			}).
+			Complete(&KubeConfigMapReconciler{SecretMirrorReconciler: rec}); err != nil {
+			return err
+		}
+		log.Info("kubeconfig configmap source enabled", "selector", opts.ConfigMapSelector.String())
+	}
+
+	// Optional Open Cluster Management ManagedCluster source
+	if opts.OCMSourceEnabled {
+		mc := &unstructured.Unstructured{}
+		mc.SetGroupVersionKind(managedClusterGVK)
+		ocm := &ManagedClusterReconciler{SecretMirrorReconciler: rec}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("ocm-managedcluster").
+			For(mc).
+			WatchesRawSource(source.Channel(rec.sourceTrigger("managedCluster"), &handler.EnqueueRequestForObject{})).
+			Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(ocm.secretToManagedClusters)).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: opts.MaxConcurrent,
+			}).
+			Complete(ocm); err != nil {
+			return err
+		}
+		log.Info("ocm managed cluster source enabled", "secretTemplate", opts.OCMSecretTemplateStr)
+	}
+
+	// Optional static inventory file source
+	if opts.InventoryFile != "" {
+		inv := newInventoryWatcher(mgr.GetAPIReader(), opts)
+		rec.sourceTriggers["inventory"] = inv.Entries.triggers
+		if err := mgr.Add(inv); err != nil {
+			return fmt.Errorf("add inventory watcher: %w", err)
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("inventory").
+			WatchesRawSource(source.Channel(inv.Entries.triggers, &handler.EnqueueRequestForObject{})).
+			Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(inv.Entries.secretToEntries(opts.RSIPNamespace))).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: opts.MaxConcurrent,
+			}).
+			Complete(&InventoryReconciler{SecretMirrorReconciler: rec, Entries: inv.Entries}); err != nil {
+			return err
+		}
+		log.Info("inventory file source enabled", "file", opts.InventoryFile)
+	}
+
+	// Optional external CMDB
+	if opts.CMDBURL != "" {
+		rec.CMDB = newCMDBPoller(mgr.GetAPIReader(), opts, rec.requeueSource)
+		if err := mgr.Add(rec.CMDB); err != nil {
+			return fmt.Errorf("add cmdb poller: %w", err)
+		}
+		if rec.CMDB.Entries != nil {
+			rec.sourceTriggers["cmdb"] = rec.CMDB.Entries.triggers
+			if err := ctrl.NewControllerManagedBy(mgr).
+				Named("cmdb").
+				WatchesRawSource(source.Channel(rec.CMDB.Entries.triggers, &handler.EnqueueRequestForObject{})).
+				Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(rec.CMDB.Entries.secretToEntries(opts.RSIPNamespace))).
+				WithOptions(controller.Options{
+					CacheSyncTimeout:        opts.CacheSyncTimeout,
+					RecoverPanic:            boolPtr(true),
+					MaxConcurrentReconciles: opts.MaxConcurrent,
+				}).
+				Complete(&InventoryReconciler{SecretMirrorReconciler: rec, Entries: rec.CMDB.Entries}); err != nil {
+				return err
+			}
+		}
+		log.Info("cmdb source enabled", "url", opts.CMDBURL,
+			"interval", opts.CMDBInterval.String(), "generate", opts.CMDBGenerate)
+	}
+
+	// Optional remote management clusters (hub-of-hubs)
+	remoteReaders := map[string]client.Reader{}
+	for _, name := range slices.Sorted(maps.Keys(opts.RemoteKubeconfigs)) {
+		cl, err := newRemoteCluster(opts.RemoteKubeconfigs[name], func(o *cluster.Options) {
+			o.Scheme = mgr.GetScheme()
+		})
+		if err != nil {
+			return fmt.Errorf("remote cluster %s: %w", name, err)
+		}
+		if err := mgr.Add(cl); err != nil {
+			return fmt.Errorf("add remote cluster %s: %w", name, err)
+		}
+		remoteReaders[name] = cl.GetAPIReader()
+
+		remotePred := predicate.TypedFuncs[*corev1.Secret]{
+			CreateFunc: func(e event.TypedCreateEvent[*corev1.Secret]) bool { return remoteSelected(e.Object, opts) },
+			// old object too, so a Secret that stops matching gets its copy removed
+			UpdateFunc: func(e event.TypedUpdateEvent[*corev1.Secret]) bool {
+				return remoteSelected(e.ObjectOld, opts) || remoteSelected(e.ObjectNew, opts)
+			},
+			DeleteFunc:  func(e event.TypedDeleteEvent[*corev1.Secret]) bool { return true },
+			GenericFunc: func(e event.TypedGenericEvent[*corev1.Secret]) bool { return remoteSelected(e.Object, opts) },
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("remote-"+name).
+			WatchesRawSource(source.Kind(cl.GetCache(), &corev1.Secret{},
+				&handler.TypedEnqueueRequestForObject[*corev1.Secret]{}, remotePred)).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: 1,
+			}).
+			Complete(&RemoteSecretReconciler{
+				Client:        mgr.GetClient(),
+				APIReader:     mgr.GetAPIReader(),
+				Recorder:      rec.Recorder,
+				Opts:          opts,
+				SourceCluster: name,
+				Remote:        cl.GetClient(),
+			}); err != nil {
+			return err
+		}
+		log.Info("remote management cluster enabled", "sourceCluster", name, "target", opts.RemoteTargetNamespace)
+	}
+
+	// Optional RSIP for the management cluster itself
+	if opts.LocalClusterEnabled {
+		if err := mgr.Add(&localClusterRSIP{SecretMirrorReconciler: rec}); err != nil {
+			return fmt.Errorf("add local cluster RSIP: %w", err)
+		}
+		log.Info("local cluster RSIP enabled", "name", opts.LocalClusterName)
+	}
+
+	// Optional SIG-Multicluster ClusterProfile source
+	if opts.ClusterProfileSourceEnabled {
+		cp := &unstructured.Unstructured{}
+		cp.SetGroupVersionKind(clusterProfileGVK)
+		cpPred := predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool { return e.Object.GetLabels()[clusterProfileOutputLabel] == "" },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return e.ObjectNew.GetLabels()[clusterProfileOutputLabel] == ""
+			},
+			DeleteFunc:  func(e event.DeleteEvent) bool { return true },
+			GenericFunc: func(e event.GenericEvent) bool { return e.Object.GetLabels()[clusterProfileOutputLabel] == "" },
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("clusterprofile").
+			For(cp, builder.WithPredicates(cpPred)).
+			WatchesRawSource(source.Channel(rec.sourceTrigger("clusterProfile"), &handler.EnqueueRequestForObject{})).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: opts.MaxConcurrent,
+			}).
+			Complete(&ClusterProfileReconciler{SecretMirrorReconciler: rec}); err != nil {
+			return err
+		}
+		log.Info("cluster profile source enabled")
+	}
+
+	// Optional SIG-Multicluster ClusterProfile output
+	if opts.ClusterProfileOutputEnabled {
+		rec.ClusterProfileOutput = &clusterProfileWriter{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  rec.Recorder,
+			Opts:      opts,
+		}
+		log.Info("cluster profile output enabled", "namespace", opts.ClusterProfileOutputNamespace)
+	}
+
+	// Optional ResourceSet spec.inputs sink
+	if opts.ResourceSetInputsEnabled {
+		rs := &unstructured.Unstructured{}
+		rs.SetGroupVersionKind(resourceSetGVK)
+		rsip := &unstructured.Unstructured{}
+		rsip.SetGroupVersionKind(rsipGVK)
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("resourceset-inputs").
+			For(rs, builder.WithPredicates(resourceSetInputsPredicate())).
+			Watches(rsip, handler.EnqueueRequestsFromMapFunc(rsipToResourceSets(mgr.GetClient())),
+				builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+					return obj.GetLabels()["mirror.fluxcd.io/managed"] == "true"
+				}))).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: 1,
+			}).
+			Complete(&ResourceSetInputsReconciler{
+				Client:    mgr.GetClient(),
+				APIReader: mgr.GetAPIReader(),
+				Recorder:  rec.Recorder,
+			}); err != nil {
+			return err
+		}
+		log.Info("resourceset inputs sink enabled", "annotation", inputsSelectorAnnotation)
+	}
+
+	// Optional ResourceSet reconciliation requests
+	if opts.ResourceSetTriggerEnabled {
+		if err := mgr.Add(&resourceSetTrigger{
+			Cache:     mgr.GetCache(),
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Interval:  opts.ResourceSetTriggerInterval,
+		}); err != nil {
+			return fmt.Errorf("add resourceset trigger: %w", err)
+		}
+		log.Info("resourceset trigger enabled", "interval", opts.ResourceSetTriggerInterval.String())
+	}
+
+	// Optional rollout status aggregation
+	if opts.RolloutStatusEnabled {
+		if err := mgr.Add(&rolloutAggregator{
+			Cache:     mgr.GetCache(),
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Opts:      opts,
+		}); err != nil {
+			return fmt.Errorf("add rollout aggregator: %w", err)
+		}
+		log.Info("rollout status enabled", "configMap", opts.RolloutStatusConfigMap)
+	}
+
+	// Optional fleet inventory summary
+	if opts.FleetSummaryConfigMap != "" {
+		if err := mgr.Add(&fleetSummary{
+			Cache:     mgr.GetCache(),
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Opts:      opts,
+		}); err != nil {
+			return fmt.Errorf("add fleet summary: %w", err)
+		}
+		log.Info("fleet summary enabled", "configMap", opts.FleetSummaryNamespace+"/"+opts.FleetSummaryConfigMap)
+	}
+
+	// Optional read-only inventory API
+	if opts.InventoryAPIBindAddress != "" {
+		rec.State = newClusterState()
+		if err := mgr.Add(&inventoryAPI{State: rec.State, BindAddress: opts.InventoryAPIBindAddress}); err != nil {
+			return fmt.Errorf("add inventory API: %w", err)
+		}
+		log.Info("inventory API enabled", "address", opts.InventoryAPIBindAddress)
+	}
+
+	// Optional kubeconfig replication into the RSIP namespace
+	if opts.ReplicateKubeconfigs {
+		rec.Replicator = &kubeconfigReplicator{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  rec.Recorder,
+		}
+		log.Info("kubeconfig replication enabled")
+	}
+
+	// Optional Argo CD cluster Secret output
+	if opts.ArgoCDOutputEnabled {
+		rec.ArgoOutput = &argoClusterWriter{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Recorder:  rec.Recorder,
+			Opts:      opts,
+		}
+		log.Info("argo cd cluster secret output enabled", "namespace", opts.ArgoCDOutputNamespace)
+	}
+
+	// Optional Argo CD cluster Secret source
+	if opts.ArgoCDSourceEnabled {
+		argoPred := predicate.Funcs{
+			CreateFunc: func(e event.CreateEvent) bool { return isArgoClusterSecret(e.Object, opts) },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return isArgoClusterSecret(e.ObjectOld, opts) || isArgoClusterSecret(e.ObjectNew, opts)
+			},
+			DeleteFunc:  func(e event.DeleteEvent) bool { return e.Object.GetNamespace() == opts.ArgoCDNamespace },
+			GenericFunc: func(e event.GenericEvent) bool { return isArgoClusterSecret(e.Object, opts) },
+		}
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named("argocd-cluster").
+			For(&corev1.Secret{}, builder.WithPredicates(argoPred)).
+			WithOptions(controller.Options{
+				CacheSyncTimeout:        opts.CacheSyncTimeout,
+				RecoverPanic:            boolPtr(true),
+				MaxConcurrentReconciles: 1,
+			}).
+			Complete(&ArgoClusterReconciler{
+				Client:    mgr.GetClient(),
+				APIReader: mgr.GetAPIReader(),
+				Recorder:  rec.Recorder,
+				Opts:      opts,
+			}); err != nil {
+			return err
+		}
+		log.Info("argo cd cluster secret source enabled",
+			"namespace", opts.ArgoCDNamespace, "target", opts.ArgoCDTargetNamespace)
+	}
+
 	// Periodic GC runnable
 	gcLog := ctrl.Log.WithName("gc")
+	sweep := func(ctx context.Context) error {
+		if opts.ArgoCDSourceEnabled {
+			if err := sweepOrphanArgoSecrets(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.ArgoCDTargetNamespace); err != nil {
+				gcLog.Error(err, "argo cd secret sweep failed")
+			}
+		}
+		if opts.ArgoCDOutputEnabled {
+			if err := sweepOrphanArgoOutputSecrets(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.ArgoCDOutputNamespace); err != nil {
+				gcLog.Error(err, "argo cd output sweep failed")
+			}
+		}
+		if opts.ReplicateKubeconfigs {
+			if err := sweepOrphanReplicas(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient()); err != nil {
+				gcLog.Error(err, "kubeconfig replica sweep failed")
+			}
+		}
+		if !opts.LocalClusterEnabled {
+			if err := sweepLocalRSIPs(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), types.NamespacedName{}); err != nil {
+				gcLog.Error(err, "local cluster RSIP sweep failed")
+			}
+		}
+		if len(remoteReaders) > 0 {
+			if err := sweepOrphanRemoteCopies(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.RemoteTargetNamespace, remoteReaders); err != nil {
+				gcLog.Error(err, "remote secret copy sweep failed")
+			}
+		}
+		if opts.ClusterProfileOutputEnabled {
+			if err := sweepOrphanClusterProfiles(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.ClusterProfileOutputNamespace); err != nil {
+				gcLog.Error(err, "cluster profile output sweep failed")
+			}
+		}
+		return sweepOrphanRSIPs(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient())
+	}
 	return mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		ticker := time.NewTicker(2 * time.Minute)
 		defer ticker.Stop()
 
-		if err := sweepOrphanRSIPs(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.RSIPNamespace); err != nil {
+		if err := sweep(ctx); err != nil {
 			gcLog.Error(err, "initial GC sweep failed")
 		}
 		for {
@@ -126,7 +551,7 @@ func SetupRSIPController(mgr manager.Manager, opts Options) error {
 			case <-ctx.Done():
 				return nil
 			case <-ticker.C:
-				if err := sweepOrphanRSIPs(ctx, gcLog, mgr.GetAPIReader(), mgr.GetClient(), opts.RSIPNamespace); err != nil {
+				if err := sweep(ctx); err != nil {
 					gcLog.Error(err, "periodic GC sweep failed")
 				}
 			}
@@ -134,6 +559,13 @@ func SetupRSIPController(mgr manager.Manager, opts Options) error {
 	}))
 }
 
+// isArgoClusterSecret reports whether obj is an Argo CD cluster Secret in the configured namespace.
+func isArgoClusterSecret(obj client.Object, opts Options) bool {
+	return obj.GetNamespace() == opts.ArgoCDNamespace &&
+		obj.GetLabels()[argoSecretTypeLabel] == "cluster" &&
+		obj.GetLabels()[argoOutputLabel] == ""
+}
+
 // tiny helper converting []string to a set
 type setsString struct{ m map[string]struct{} }
 
@@ -0,0 +1,106 @@
+// internal/controller/vci_enrichment.go
+package controller
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var vciGVK = schema.GroupVersionKind{
+	Group:   "storage.loft.sh",
+	Version: "v1",
+	Kind:    "VirtualClusterInstance",
+}
+
+const (
+	// Labels the vcluster-platform-flux-secret-controller puts on kubeconfig Secrets.
+	vciNameLabel      = "vci.flux.loft.sh/name"
+	vciNamespaceLabel = "vci.flux.loft.sh/namespace"
+
+	// sleepingSinceAnnotation is set by vCluster Platform while an instance sleeps.
+	sleepingSinceAnnotation = "sleepmode.loft.sh/sleeping-since"
+)
+
+// Policies for Secrets whose VirtualClusterInstance is sleeping.
+const (
+	VCISleepPolicyMark     = "mark"     // keep the RSIP, label it sleeping
+	VCISleepPolicyWithdraw = "withdraw" // delete the RSIP until the instance wakes up
+)
+
+// vciInfo is what the RSIP takes from a VirtualClusterInstance.
+type vciInfo struct {
+	Name              string
+	Namespace         string
+	Labels            map[string]string
+	TemplateName      string
+	KubernetesVersion string
+	Sleeping          bool
+}
+
+// lookupVCI finds the VirtualClusterInstance a Secret was generated for, using its
+// vci.flux.loft.sh/* labels. found is false when the labels are missing or the instance is gone.
+func lookupVCI(ctx context.Context, c client.Reader, secLabels map[string]string) (info vciInfo, found bool, err error) {
+	name, ns := secLabels[vciNameLabel], secLabels[vciNamespaceLabel]
+	if name == "" || ns == "" {
+		return info, false, nil
+	}
+	var u unstructured.Unstructured
+	u.SetGroupVersionKind(vciGVK)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &u); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return info, false, nil
+		}
+		return info, false, err
+	}
+	return vciInfoFrom(&u), true, nil
+}
+
+func vciInfoFrom(u *unstructured.Unstructured) vciInfo {
+	info := vciInfo{Name: u.GetName(), Namespace: u.GetNamespace(), Labels: u.GetLabels()}
+	info.TemplateName, _, _ = unstructured.NestedString(u.Object, "spec", "templateRef", "name")
+
+	// The version lives in different places depending on how the instance was created.
+	for _, path := range [][]string{
+		{"status", "virtualCluster", "kubernetesVersion"},
+		{"spec", "template", "kubernetesVersion"},
+		{"spec", "templateRef", "version"},
+	} {
+		if v, _, _ := unstructured.NestedString(u.Object, path...); v != "" {
+			info.KubernetesVersion = v
+			break
+		}
+	}
+
+	phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+	_, sleepingSince := u.GetAnnotations()[sleepingSinceAnnotation]
+	info.Sleeping = sleepingSince || strings.EqualFold(phase, "Sleeping")
+	return info
+}
+
+// vciToSecrets maps a VirtualClusterInstance event to the kubeconfig Secrets labeled with it.
+func vciToSecrets(c client.Reader) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var list corev1.SecretList
+		if err := c.List(ctx, &list, client.MatchingLabels{
+			vciNameLabel:      obj.GetName(),
+			vciNamespaceLabel: obj.GetNamespace(),
+		}); err != nil {
+			ctrl.Log.WithName("vci").Error(err, "list secrets for VirtualClusterInstance failed",
+				"vci", client.ObjectKeyFromObject(obj).String())
+			return nil
+		}
+		reqs := make([]reconcile.Request, 0, len(list.Items))
+		for i := range list.Items {
+			reqs = append(reqs, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&list.Items[i])})
+		}
+		return reqs
+	}
+}